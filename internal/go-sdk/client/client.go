@@ -2,9 +2,8 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
@@ -14,12 +13,16 @@ import (
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/go-openapi/strfmt"
-	"github.com/hashicorp/go-cleanhttp"
 )
 
 const (
 	WatsonStudioService             = "WatsonStudio"
 	WatsonMachineLearningAPIVersion = "2021-12-01"
+	DefaultHTTPTimeout              = 30 * time.Second
+	DefaultMaxRetries               = 4
+	DefaultMaxRetryInterval         = 30 * time.Second
+
+	WMLInstanceIDHeader = "ML-Instance-ID"
 )
 
 var (
@@ -28,7 +31,33 @@ var (
 )
 
 type Config struct {
-	URL string
+	URL       string
+	Platform  string
+	Region    string
+	Endpoints Endpoints
+	TLS       TLSConfig
+
+	// MaxRetries is the number of retries of requests failing with 429, 5xx
+	// or a connection error, 0 disables retries. Retry-After is honored,
+	// otherwise the wait time grows exponentially up to MaxRetryInterval.
+	MaxRetries       int
+	MaxRetryInterval time.Duration
+
+	// ProxyURL and NoProxy override HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+	ProxyURL string
+	NoProxy  string
+
+	// DefaultHeaders are sent with every request of the services, e.g. a
+	// tenant header required by a gateway.
+	DefaultHeaders http.Header
+	// WMLInstanceID is sent as ML-Instance-ID header if set.
+	WMLInstanceID string
+
+	// LogContext carries the logger for the requests of all services, since
+	// most SDK calls do not pass the context of the Terraform operation.
+	LogContext context.Context
+
+	transport http.RoundTripper
 }
 
 type Client struct {
@@ -37,21 +66,41 @@ type Client struct {
 	wml           *watsonmachinelearningv4.WatsonMachineLearningV4
 	wos           *watsonopenscalev2.WatsonOpenScaleV2
 	authenticator core.Authenticator
+
+	openScaleInstanceID string
 }
 
-func (c *Client) useDefaultRoundTripper(service *core.BaseService) {
-	tlsConfig := &tls.Config{InsecureSkipVerify: true}
-	transport := cleanhttp.DefaultPooledTransport()
-	transport.TLSClientConfig = tlsConfig
+// configureService makes service use the shared transport, retry settings
+// and default headers. Requests built by hand and sent with service.Client are
+// retried as well, but have to add service.DefaultHeaders themselves.
+func (c *Client) configureService(service *core.BaseService) error {
+	transport, err := c.Config.Transport()
+	if err != nil {
+		return err
+	}
 	service.Client.Transport = transport
-	// service.Client.Transport = c.Config.defaultRoundTripper
+	service.SetDefaultHeaders(c.Config.defaultHeaders())
+	if c.Config.MaxRetries > 0 {
+		service.EnableRetries(c.Config.MaxRetries, c.Config.MaxRetryInterval)
+	}
+	return nil
 }
 
-func (c *Config) IsPublicCloud() bool {
-	return strings.Contains(c.URL, "cloud.ibm.com")
+func (c *Config) defaultHeaders() http.Header {
+	headers := c.DefaultHeaders.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	if c.WMLInstanceID != "" {
+		headers.Set(WMLInstanceIDHeader, c.WMLInstanceID)
+	}
+	return headers
 }
 
 func NewClient(authenticator core.Authenticator, config *Config) (*Client, error) {
+	if _, err := config.Transport(); err != nil {
+		return nil, err
+	}
 	client := &Client{
 		authenticator: authenticator,
 		Config:        config,
@@ -63,14 +112,17 @@ func (c *Client) SpaceClient(ctx context.Context) (*spacev2.SpaceV2, error) {
 	var err error
 	if c.space == nil {
 		serviceOptions := spacev2.SpaceV2Options{
-			URL:           utils.If(strings.Contains(c.Config.URL, "cloud.ibm.com"), "https://api.dataplatform.cloud.ibm.com", c.Config.URL),
+			URL:           c.Config.SpacesURL(),
 			Authenticator: c.authenticator,
 		}
 		c.space, err = spacev2.NewSpaceV2(&serviceOptions)
 		if err != nil {
 			return nil, err
 		}
-		c.useDefaultRoundTripper(c.space.Service)
+		if err := c.configureService(c.space.Service); err != nil {
+			c.space = nil
+			return nil, err
+		}
 	}
 	return c.space, err
 }
@@ -79,7 +131,7 @@ func (c *Client) WMLClient(ctx context.Context) (*watsonmachinelearningv4.Watson
 	var err error
 	if c.wml == nil {
 		serviceOptions := watsonmachinelearningv4.WatsonMachineLearningV4Options{
-			URL:           c.Config.URL + "/ml",
+			URL:           c.Config.WMLURL(),
 			Authenticator: c.authenticator,
 			Version:       &WatsonMachineLearningAPIVersionDate,
 		}
@@ -87,24 +139,49 @@ func (c *Client) WMLClient(ctx context.Context) (*watsonmachinelearningv4.Watson
 		if err != nil {
 			return nil, err
 		}
-		c.useDefaultRoundTripper(c.wml.Service)
+		if err := c.configureService(c.wml.Service); err != nil {
+			c.wml = nil
+			return nil, err
+		}
 	}
 	return c.wml, err
 }
 
+// ListOpenScaleInstances returns the Watson OpenScale instances of the account
+// from the IBM Cloud resource controller.
+func (c *Client) ListOpenScaleInstances(ctx context.Context, filter utils.OpenScaleInstanceFilter) ([]utils.OpenScaleInstance, error) {
+	httpClient, err := c.Config.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	return utils.ListOpenScaleInstances(ctx, c.authenticator, httpClient, c.Config.ResourceControllerURL(), filter)
+}
+
+// OpenScaleInstanceID returns the ID of the Watson OpenScale instance that
+// WOSClient uses, resolving it on first use.
+func (c *Client) OpenScaleInstanceID(ctx context.Context) (string, error) {
+	if _, err := c.WOSClient(ctx); err != nil {
+		return "", err
+	}
+	return c.openScaleInstanceID, nil
+}
+
 func (c *Client) WOSClient(ctx context.Context) (*watsonopenscalev2.WatsonOpenScaleV2, error) {
 	var err error
 	if c.wos == nil {
-		var url string
-		if strings.Contains(c.Config.URL, "cloud.ibm.com") {
-			guid, err := utils.GetOpenScaleGuid(c.authenticator)
+		instanceID := c.Config.Endpoints.OpenScaleInstanceID
+		if instanceID == "" && c.Config.IsPublicCloud() {
+			httpClient, err := c.Config.HTTPClient()
+			if err != nil {
+				return nil, err
+			}
+			instanceID, err = utils.GetOpenScaleGuid(ctx, c.authenticator, httpClient, c.Config.ResourceControllerURL(), c.Config.region())
 			if err != nil {
 				return nil, err
 			}
-			url = fmt.Sprintf("https://api.aiopenscale.cloud.ibm.com/openscale/%s", guid)
-		} else {
-			url = fmt.Sprintf("%s/openscale/00000000-0000-0000-0000-000000000000", c.Config.URL)
 		}
+		c.openScaleInstanceID = utils.If(instanceID != "", instanceID, DefaultOpenScaleInstanceID)
+		url := fmt.Sprintf("%s/openscale/%s", c.Config.WOSURL(), c.openScaleInstanceID)
 
 		serviceOptions := watsonopenscalev2.WatsonOpenScaleV2Options{
 			URL:           url,
@@ -114,7 +191,10 @@ func (c *Client) WOSClient(ctx context.Context) (*watsonopenscalev2.WatsonOpenSc
 		if err != nil {
 			return nil, err
 		}
-		c.useDefaultRoundTripper(c.wos.Service)
+		if err := c.configureService(c.wos.Service); err != nil {
+			c.wos = nil
+			return nil, err
+		}
 	}
 	return c.wos, err
 }
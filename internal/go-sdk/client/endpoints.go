@@ -0,0 +1,153 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/utils"
+)
+
+const (
+	PlatformCPD   = "cpd"
+	PlatformCloud = "cloud"
+
+	DefaultRegion              = "us-south"
+	DefaultOpenScaleInstanceID = "00000000-0000-0000-0000-000000000000"
+)
+
+var Platforms = []string{PlatformCPD, PlatformCloud}
+
+var regionPattern = regexp.MustCompile(`^[a-z]{2}-[a-z]+$`)
+
+// Endpoints overrides the URLs of individual services. Empty values are
+// resolved from the platform, region and URL of the configuration.
+type Endpoints struct {
+	WMLURL                string
+	WOSURL                string
+	SpacesURL             string
+	DataplatformURL       string
+	ResourceControllerURL string
+	OpenScaleInstanceID   string
+}
+
+func (c *Config) IsPublicCloud() bool {
+	return c.Platform == PlatformCloud
+}
+
+func (c *Config) region() string {
+	if c.Region != "" {
+		return c.Region
+	}
+	if region := c.URLRegion(); region != "" {
+		return region
+	}
+	return DefaultRegion
+}
+
+// cloudHost returns the host of URL if it is an IBM Cloud endpoint.
+func (c *Config) cloudHost() string {
+	u, err := url.Parse(c.URL)
+	if err != nil || !strings.HasSuffix(u.Hostname(), ".cloud.ibm.com") {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// URLRegion returns the IBM Cloud region in the host of URL, e.g. eu-de for
+// https://eu-de.ml.cloud.ibm.com, or "" if URL has no region.
+func (c *Config) URLRegion() string {
+	for _, label := range strings.Split(c.cloudHost(), ".") {
+		if regionPattern.MatchString(label) {
+			return label
+		}
+	}
+	return ""
+}
+
+// cloudURL returns URL if it is the IBM Cloud endpoint of the service with
+// the given host suffix, e.g. ml.cloud.ibm.com.
+func (c *Config) cloudURL(suffix string) (string, bool) {
+	host := c.cloudHost()
+	if host == suffix || strings.HasSuffix(host, "."+suffix) {
+		return c.URL, true
+	}
+	return "", false
+}
+
+// WMLURL returns the URL of Watson Machine Learning.
+func (c *Config) WMLURL() string {
+	if c.Endpoints.WMLURL != "" {
+		return strings.TrimSuffix(c.Endpoints.WMLURL, "/")
+	}
+	if c.IsPublicCloud() {
+		if cloudURL, ok := c.cloudURL("ml.cloud.ibm.com"); ok {
+			return cloudURL + "/ml"
+		}
+		return fmt.Sprintf("https://%s.ml.cloud.ibm.com/ml", c.region())
+	}
+	return c.URL + "/ml"
+}
+
+// SpacesURL returns the URL of the data platform API, which serves spaces and
+// platform jobs.
+func (c *Config) SpacesURL() string {
+	if c.Endpoints.SpacesURL != "" {
+		return strings.TrimSuffix(c.Endpoints.SpacesURL, "/")
+	}
+	if c.IsPublicCloud() {
+		if cloudURL, ok := c.cloudURL("dataplatform.cloud.ibm.com"); ok && strings.HasPrefix(c.cloudHost(), "api.") {
+			return cloudURL
+		}
+		if c.region() == DefaultRegion {
+			return "https://api.dataplatform.cloud.ibm.com"
+		}
+		return fmt.Sprintf("https://api.%s.dataplatform.cloud.ibm.com", c.region())
+	}
+	return c.URL
+}
+
+// DataplatformURL returns the URL of the data platform, which serves the
+// project APIs, e.g. to promote assets.
+func (c *Config) DataplatformURL() string {
+	if c.Endpoints.DataplatformURL != "" {
+		return strings.TrimSuffix(c.Endpoints.DataplatformURL, "/")
+	}
+	if c.IsPublicCloud() {
+		if cloudURL, ok := c.cloudURL("dataplatform.cloud.ibm.com"); ok && !strings.HasPrefix(c.cloudHost(), "api.") {
+			return cloudURL
+		}
+		if c.region() == DefaultRegion {
+			return "https://dataplatform.cloud.ibm.com"
+		}
+		return fmt.Sprintf("https://%s.dataplatform.cloud.ibm.com", c.region())
+	}
+	return c.URL
+}
+
+// ResourceControllerURL returns the URL of the IBM Cloud resource controller,
+// used to look up Watson OpenScale instances.
+func (c *Config) ResourceControllerURL() string {
+	if c.Endpoints.ResourceControllerURL != "" {
+		return strings.TrimSuffix(c.Endpoints.ResourceControllerURL, "/")
+	}
+	return utils.RESOURCE_CONTROLLER_URL
+}
+
+// WOSURL returns the URL of Watson OpenScale, without the service instance.
+func (c *Config) WOSURL() string {
+	if c.Endpoints.WOSURL != "" {
+		return strings.TrimSuffix(c.Endpoints.WOSURL, "/")
+	}
+	if c.IsPublicCloud() {
+		if cloudURL, ok := c.cloudURL("aiopenscale.cloud.ibm.com"); ok {
+			return cloudURL
+		}
+		if c.region() == DefaultRegion {
+			return "https://api.aiopenscale.cloud.ibm.com"
+		}
+		return fmt.Sprintf("https://%s.api.aiopenscale.cloud.ibm.com", c.region())
+	}
+	return c.URL
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// maxLoggedBodySize limits the bodies logged at TRACE, so that uploaded
+	// and downloaded archives are not read into memory.
+	maxLoggedBodySize = 64 * 1024
+
+	redacted = "REDACTED"
+)
+
+// sensitiveKeys are matched against header names, query parameters and JSON
+// or form fields after removing "-" and "_" and converting to lower case.
+var sensitiveKeys = []string{"password", "passwd", "apikey", "token", "secret", "accesskey", "privatekey", "authorization", "cookie", "credentials"}
+
+// correlationHeaders identify a request in the logs of IBM Cloud Pak for Data
+// and IBM Cloud.
+var correlationHeaders = []string{"X-Global-Transaction-Id", "X-Request-Id", "X-Correlation-Id"}
+
+// loggingTransport logs every request at DEBUG with method, URL, status,
+// latency and correlation IDs, and the headers and bodies at TRACE. Secrets
+// are redacted.
+type loggingTransport struct {
+	base      http.RoundTripper
+	ctx       context.Context
+	logBodies bool
+}
+
+func newLoggingTransport(ctx context.Context, base http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		base:      base,
+		ctx:       ctx,
+		logBodies: traceLogging(),
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    redactURL(req.URL),
+	}
+
+	if t.logBodies {
+		traceFields := map[string]interface{}{
+			"http_method":          req.Method,
+			"http_url":             redactURL(req.URL),
+			"http_request_headers": redactHeaders(req.Header),
+		}
+		if body, ok := readRequestBody(req); ok {
+			traceFields["http_request_body"] = redactBody(req.Header.Get("Content-Type"), body)
+		}
+		tflog.Trace(t.ctx, "Sending HTTP request", traceFields)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(t.ctx, "HTTP request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	for _, header := range correlationHeaders {
+		if value := resp.Header.Get(header); value != "" {
+			fields[strings.ToLower(strings.ReplaceAll(header, "-", "_"))] = value
+		}
+	}
+	tflog.Debug(t.ctx, "HTTP request", fields)
+
+	if t.logBodies {
+		traceFields := map[string]interface{}{
+			"http_status":           resp.StatusCode,
+			"http_response_headers": redactHeaders(resp.Header),
+		}
+		if body, ok := readResponseBody(resp); ok {
+			traceFields["http_response_body"] = redactBody(resp.Header.Get("Content-Type"), body)
+		}
+		tflog.Trace(t.ctx, "Received HTTP response", traceFields)
+	}
+
+	return resp, nil
+}
+
+// traceLogging reports whether the provider logs at TRACE, in which case
+// bodies are read for logging.
+func traceLogging() bool {
+	for _, env := range []string{"TF_LOG_PROVIDER_IBMCPD", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(os.Getenv(env)); level != "" {
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
+// readRequestBody returns the body of req and restores it, if it is textual
+// and small enough to be logged.
+func readRequestBody(req *http.Request) ([]byte, bool) {
+	if req.Body == nil || req.Body == http.NoBody || !loggableContent(req.Header.Get("Content-Type")) {
+		return nil, false
+	}
+	if req.ContentLength < 0 || req.ContentLength > maxLoggedBodySize {
+		return nil, false
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err == nil
+}
+
+// readResponseBody returns the body of resp and restores it, if it is textual
+// and small enough to be logged.
+func readResponseBody(resp *http.Response) ([]byte, bool) {
+	if resp.Body == nil || resp.Body == http.NoBody || !loggableContent(resp.Header.Get("Content-Type")) {
+		return nil, false
+	}
+	if resp.ContentLength > maxLoggedBodySize {
+		return nil, false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBodySize+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	return body, err == nil && len(body) <= maxLoggedBodySize
+}
+
+func loggableContent(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(mediaType, "json") || mediaType == "application/x-www-form-urlencoded" || strings.HasPrefix(mediaType, "text/")
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(key))
+	for _, v := range sensitiveKeys {
+		if strings.Contains(key, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	redactedURL.User = nil
+	query := redactedURL.Query()
+	for key := range query {
+		if isSensitive(key) {
+			query.Set(key, redacted)
+		}
+	}
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		headers[key] = header.Get(key)
+		if isSensitive(key) {
+			headers[key] = redacted
+		}
+	}
+	return headers
+}
+
+// redactBody returns body with the values of sensitive JSON or form fields
+// replaced. Other text is not logged, since it cannot be redacted.
+func redactBody(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasSuffix(mediaType, "json"):
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return "[invalid JSON]"
+		}
+		redacted, err := json.Marshal(redactJSON(value))
+		if err != nil {
+			return "[invalid JSON]"
+		}
+		return string(redacted)
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "[invalid form]"
+		}
+		for key := range values {
+			if isSensitive(key) {
+				values.Set(key, redacted)
+			}
+		}
+		return values.Encode()
+	}
+	return "[" + mediaType + " body not logged]"
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// proxy returns the proxy function of the transport. The proxy settings of
+// the configuration take precedence over HTTPS_PROXY, HTTP_PROXY and NO_PROXY.
+func (c *Config) proxy() (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %s, expected e.g. http://proxy.example.com:3128", c.ProxyURL)
+		}
+		proxyConfig.HTTPProxy = c.ProxyURL
+		proxyConfig.HTTPSProxy = c.ProxyURL
+	}
+	if c.NoProxy != "" {
+		proxyConfig.NoProxy = c.NoProxy
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
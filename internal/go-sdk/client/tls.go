@@ -0,0 +1,124 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// TLSConfig holds the TLS settings shared by all services and the
+// authenticator. Certificates can be given as a file path or as PEM content.
+type TLSConfig struct {
+	InsecureSkipVerify bool
+	CACertFile         string
+	CACertPEM          string
+	ClientCertFile     string
+	ClientCertPEM      string
+	ClientKeyFile      string
+	ClientKeyPEM       string
+}
+
+// Transport returns the HTTP transport used for all requests of the provider.
+// It is created on first use so that the authenticator and the services share
+// one connection pool, and logs all requests if LogContext is set.
+func (c *Config) Transport() (http.RoundTripper, error) {
+	if c.transport == nil {
+		tlsConfig, err := c.TLS.build()
+		if err != nil {
+			return nil, err
+		}
+		proxy, err := c.proxy()
+		if err != nil {
+			return nil, err
+		}
+		transport := cleanhttp.DefaultPooledTransport()
+		transport.TLSClientConfig = tlsConfig
+		transport.Proxy = proxy
+		c.transport = transport
+		if c.LogContext != nil {
+			c.transport = newLoggingTransport(c.LogContext, transport)
+		}
+	}
+	return c.transport, nil
+}
+
+// HTTPClient returns a client using Transport and the retry settings, for
+// token requests and requests that are not made through a service.
+func (c *Config) HTTPClient() (*http.Client, error) {
+	transport, err := c.Transport()
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: transport, Timeout: DefaultHTTPTimeout}
+	if c.MaxRetries > 0 {
+		retryClient := core.NewRetryableClientWithHTTPClient(httpClient)
+		retryClient.RetryMax = c.MaxRetries
+		if c.MaxRetryInterval > 0 {
+			retryClient.RetryWaitMax = c.MaxRetryInterval
+		}
+		httpClient = retryClient.StandardClient()
+	}
+	return httpClient, nil
+}
+
+func (t *TLSConfig) build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		// #nosec G402 -- only when explicitly enabled in the provider configuration.
+		InsecureSkipVerify: t.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	caCert, err := pemContent(t.CACertPEM, t.CACertFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA certificate: %w", err)
+	}
+	if caCert != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("no PEM encoded certificates found in CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert, err := pemContent(t.ClientCertPEM, t.ClientCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client certificate: %w", err)
+	}
+	clientKey, err := pemContent(t.ClientKeyPEM, t.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client key: %w", err)
+	}
+	if (clientCert == nil) != (clientKey == nil) {
+		return nil, errors.New("client certificate and client key must be set together")
+	}
+	if clientCert != nil {
+		certificate, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// pemContent returns content if set, otherwise the content of file, or nil if
+// neither is set.
+func pemContent(content string, file string) ([]byte, error) {
+	if content != "" {
+		return []byte(content), nil
+	}
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
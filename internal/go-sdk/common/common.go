@@ -16,20 +16,10 @@
 
 package common
 
-import (
-	"os"
-)
-
-const (
-	wmlInstanceIDHeader = "ML-Instance-ID"
-)
-
-//GetSdkHeaders - common headers
+//GetSdkHeaders - common headers. The provider wide headers, e.g. ML-Instance-ID,
+//are set as default headers of the services by client.Client.
 func GetSdkHeaders(arg1, arg2, arg3 string) map[string]string {
 	headers := make(map[string]string)
 
-	wmlInstanceID := os.Getenv("WATSON_MACHINE_LEARNING_INSTANCE_ID")
-	headers[wmlInstanceIDHeader] = wmlInstanceID
-
 	return headers
 }
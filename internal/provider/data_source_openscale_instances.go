@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &openScaleInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &openScaleInstancesDataSource{}
+)
+
+func NewOpenScaleInstancesDataSource() datasource.DataSource {
+	return &openScaleInstancesDataSource{}
+}
+
+type openScaleInstancesDataSource struct {
+	client *client.Client
+}
+
+type openScaleInstancesDataSourceModel struct {
+	ID              types.String             `tfsdk:"id"`
+	Name            types.String             `tfsdk:"name"`
+	Region          types.String             `tfsdk:"region"`
+	ResourceGroupID types.String             `tfsdk:"resource_group_id"`
+	Instances       []openScaleInstanceModel `tfsdk:"instances"`
+}
+
+type openScaleInstanceModel struct {
+	ID              types.String `tfsdk:"id"`
+	CRN             types.String `tfsdk:"crn"`
+	Name            types.String `tfsdk:"name"`
+	Region          types.String `tfsdk:"region"`
+	ResourceGroupID types.String `tfsdk:"resource_group_id"`
+	State           types.String `tfsdk:"state"`
+}
+
+func (d *openScaleInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *openScaleInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openscale_instances"
+}
+
+func (d *openScaleInstancesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Watson OpenScale instances of the IBM Cloud account, optionally filtered by name, region or resource group. Only supported for the cloud platform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Only include instances with this name.",
+				Optional:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "Only include instances in this region, e.g. us-south.",
+				Optional:    true,
+			},
+			"resource_group_id": schema.StringAttribute{
+				Description: "Only include instances in the resource group with this ID.",
+				Optional:    true,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "List of instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "GUID of instance, used as openscale_instance_id.",
+							Computed:    true,
+						},
+						"crn": schema.StringAttribute{
+							Description: "CRN of instance.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of instance.",
+							Computed:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "Region of instance.",
+							Computed:    true,
+						},
+						"resource_group_id": schema.StringAttribute{
+							Description: "ID of the resource group of instance.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "State of instance, e.g. active.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *openScaleInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state openScaleInstancesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !d.client.Config.IsPublicCloud() {
+		resp.Diagnostics.AddError("Unsupported Platform", "Watson OpenScale instances can only be listed for the cloud platform, set openscale_instance_id in the provider configuration instead.")
+		return
+	}
+
+	instances, err := d.client.ListOpenScaleInstances(ctx, utils.OpenScaleInstanceFilter{
+		Name:            state.Name.ValueString(),
+		Region:          state.Region.ValueString(),
+		ResourceGroupID: state.ResourceGroupID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing OpenScale Instances", "Could not list Watson OpenScale instances, unexpected error: "+err.Error())
+		return
+	}
+
+	state.Instances = make([]openScaleInstanceModel, len(instances))
+	for i, v := range instances {
+		state.Instances[i] = openScaleInstanceModel{
+			ID:              types.StringValue(v.GUID),
+			CRN:             types.StringValue(v.CRN),
+			Name:            types.StringValue(v.Name),
+			Region:          types.StringValue(v.RegionID),
+			ResourceGroupID: types.StringValue(v.ResourceGroupID),
+			State:           types.StringValue(v.State),
+		}
+	}
+
+	state.ID = types.StringValue("placeholder")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &predictionDataSource{}
+	_ datasource.DataSourceWithConfigure        = &predictionDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &predictionDataSource{}
+)
+
+func NewPredictionDataSource() datasource.DataSource {
+	return &predictionDataSource{}
+}
+
+type predictionDataSource struct {
+	client *client.Client
+}
+
+type predictionDataSourceModel struct {
+	ID           types.String       `tfsdk:"id"`
+	DeploymentID types.String       `tfsdk:"deployment_id"`
+	SpaceID      types.String       `tfsdk:"space_id"`
+	Fields       []types.String     `tfsdk:"fields"`
+	Values       [][]types.String   `tfsdk:"values"`
+	InputFile    types.String       `tfsdk:"input_file"`
+	Predictions  []predictionsModel `tfsdk:"predictions"`
+	ResultJSON   types.String       `tfsdk:"result_json"`
+}
+
+type predictionsModel struct {
+	ID     types.String     `tfsdk:"id"`
+	Fields []types.String   `tfsdk:"fields"`
+	Values [][]types.String `tfsdk:"values"`
+}
+
+func (d *predictionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *predictionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prediction"
+}
+
+func (d *predictionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Scores input data with an online deployment and returns the predictions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of data source, the deployment ID.",
+				Computed:    true,
+			},
+			"deployment_id": schema.StringAttribute{
+				Description: "ID or serving name of the online deployment.",
+				Required:    true,
+			},
+			"space_id": schema.StringAttribute{
+				Description: "ID of the space of the deployment. If set, the deployment is looked up in the space before scoring.",
+				Optional:    true,
+			},
+			"fields": schema.ListAttribute{
+				Description: "Names of the input fields, set together with values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"values": schema.ListAttribute{
+				Description: "Input records, one list of values per record in the order of fields. Values that are numbers are sent as numbers, empty values as null.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Optional:    true,
+			},
+			"input_file": schema.StringAttribute{
+				Description: "Path of a file with the input data instead of fields and values. Either JSON with fields and values, e.g. {\"fields\": [\"age\"], \"values\": [[42]]}, or CSV with the fields in the first row if the file name ends with .csv.",
+				Optional:    true,
+			},
+			"predictions": schema.ListNestedAttribute{
+				Description: "Predictions returned by the deployment.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the prediction, for models with multiple outputs.",
+							Computed:    true,
+						},
+						"fields": schema.ListAttribute{
+							Description: "Names of the prediction fields, e.g. prediction and probability.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "Prediction values, one list per input record in the order of fields. Values that are not strings are JSON encoded.",
+							ElementType: types.ListType{ElemType: types.StringType},
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"result_json": schema.StringAttribute{
+				Description: "Predictions as returned by the deployment, JSON encoded.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *predictionDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("values"),
+			path.MatchRoot("input_file"),
+		),
+		datasourcevalidator.RequiredTogether(
+			path.MatchRoot("fields"),
+			path.MatchRoot("values"),
+		),
+	}
+}
+
+func (d *predictionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state predictionDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := watsonmachinelearningv4.InputDataArray{
+		Fields: utils.ConvertString(state.Fields),
+	}
+	for _, record := range state.Values {
+		input.Values = append(input.Values, scoringRecord(utils.ConvertString(record)))
+	}
+	if state.InputFile.ValueString() != "" {
+		var err error
+		input, err = readScoringPayload(state.InputFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("input_file"), "Unable to read input file", err.Error())
+			return
+		}
+	}
+
+	wmlClient, err := d.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	if state.SpaceID.ValueString() != "" {
+		_, response, err := wmlClient.DeploymentsGetWithContext(ctx, &watsonmachinelearningv4.DeploymentsGetOptions{
+			DeploymentID: core.StringPtr(state.DeploymentID.ValueString()),
+			SpaceID:      core.StringPtr(state.SpaceID.ValueString()),
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Getting Deployment", "Could not read deployment ID "+state.DeploymentID.ValueString()+" in space ID "+state.SpaceID.ValueString(), response, err) {
+			return
+		}
+	}
+
+	result, response, err := wmlClient.DeploymentsComputePredictionsWithContext(ctx, &watsonmachinelearningv4.DeploymentsComputePredictionsOptions{
+		DeploymentID: core.StringPtr(state.DeploymentID.ValueString()),
+		InputData:    []watsonmachinelearningv4.InputDataArray{input},
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Scoring Deployment", "Could not score deployment ID "+state.DeploymentID.ValueString(), response, err) {
+		return
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to encode predictions", err.Error())
+		return
+	}
+
+	state.Predictions = make([]predictionsModel, len(result.Predictions))
+	for i, prediction := range result.Predictions {
+		values := make([][]types.String, len(prediction.Values))
+		for j, record := range prediction.Values {
+			values[j] = make([]types.String, len(record))
+			for k, v := range record {
+				values[j][k] = predictionValue(v)
+			}
+		}
+		state.Predictions[i] = predictionsModel{
+			ID:     utils.StringValueOrNull(prediction.ID),
+			Fields: utils.StringList(prediction.Fields),
+			Values: values,
+		}
+	}
+	state.ResultJSON = types.StringValue(string(resultJSON))
+	state.ID = state.DeploymentID
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readScoringPayload reads input data from a JSON file with fields and values,
+// or from a CSV file with the fields in the first row.
+func readScoringPayload(filename string) (watsonmachinelearningv4.InputDataArray, error) {
+	var payload watsonmachinelearningv4.InputDataArray
+	file, err := os.Open(filename)
+	if err != nil {
+		return payload, err
+	}
+	defer file.Close()
+
+	if !strings.EqualFold(filepath.Ext(filename), ".csv") {
+		if err := json.NewDecoder(file).Decode(&payload); err != nil {
+			return payload, fmt.Errorf("unable to parse %s as JSON: %w", filename, err)
+		}
+		return payload, nil
+	}
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return payload, fmt.Errorf("unable to parse %s as CSV: %w", filename, err)
+	}
+	if len(records) == 0 {
+		return payload, fmt.Errorf("%s is empty, expected the fields in the first row", filename)
+	}
+	payload.Fields = records[0]
+	for _, record := range records[1:] {
+		payload.Values = append(payload.Values, scoringRecord(record))
+	}
+	return payload, nil
+}
+
+// scoringRecord converts values given as strings, sending numbers as numbers
+// and empty values as null.
+func scoringRecord(record []string) []interface{} {
+	values := make([]interface{}, len(record))
+	for i, v := range record {
+		if v == "" {
+			continue
+		}
+		if number, err := strconv.ParseFloat(v, 64); err == nil {
+			values[i] = number
+		} else {
+			values[i] = v
+		}
+	}
+	return values
+}
+
+// predictionValue returns a prediction value as string, JSON encoded unless
+// it is a string.
+func predictionValue(value interface{}) types.String {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(v)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return types.StringValue(fmt.Sprint(value))
+	}
+	return types.StringValue(string(encoded))
+}
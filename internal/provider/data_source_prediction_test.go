@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
+)
+
+func TestScoringRecord(t *testing.T) {
+	tests := []struct {
+		record []string
+		want   []interface{}
+	}{
+		{record: []string{}, want: []interface{}{}},
+		{record: []string{"42", "-1.5", "1e3"}, want: []interface{}{42.0, -1.5, 1000.0}},
+		{record: []string{"female", "", "3"}, want: []interface{}{"female", nil, 3.0}},
+		{record: []string{" 7", "NaN-ish"}, want: []interface{}{" 7", "NaN-ish"}},
+	}
+	for _, tt := range tests {
+		if got := scoringRecord(tt.record); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("scoringRecord(%q) = %#v, want %#v", tt.record, got, tt.want)
+		}
+	}
+}
+
+func TestReadScoringPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     watsonmachinelearningv4.InputDataArray
+		wantErr  string
+	}{
+		{
+			name:     "json",
+			filename: "payload.json",
+			content:  `{"fields": ["age", "sex"], "values": [[35, "female"], [null, "male"]]}`,
+			want: watsonmachinelearningv4.InputDataArray{
+				Fields: []string{"age", "sex"},
+				Values: [][]interface{}{{35.0, "female"}, {nil, "male"}},
+			},
+		},
+		{
+			name:     "csv",
+			filename: "payload.CSV",
+			content:  "age,sex,note\n35,female,\n,male,\"a, b\"\n",
+			want: watsonmachinelearningv4.InputDataArray{
+				Fields: []string{"age", "sex", "note"},
+				Values: [][]interface{}{{35.0, "female", nil}, {nil, "male", "a, b"}},
+			},
+		},
+		{
+			name:     "csv header only",
+			filename: "payload.csv",
+			content:  "age,sex\n",
+			want: watsonmachinelearningv4.InputDataArray{
+				Fields: []string{"age", "sex"},
+			},
+		},
+		{
+			name:     "empty csv",
+			filename: "payload.csv",
+			content:  "",
+			wantErr:  "is empty, expected the fields in the first row",
+		},
+		{
+			name:     "invalid csv",
+			filename: "payload.csv",
+			content:  "age,sex\n35\n",
+			wantErr:  "as CSV",
+		},
+		{
+			name:     "invalid json",
+			filename: "payload.json",
+			content:  "age,sex\n35,female\n",
+			wantErr:  "as JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), tt.filename)
+			if err := os.WriteFile(filename, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readScoringPayload(filename)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readScoringPayload() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readScoringPayload() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readScoringPayload() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
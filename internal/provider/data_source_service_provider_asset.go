@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"net/url"
 
 	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonopenscalev2"
+	"terraform-provider-ibmcpd/internal/utils"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -107,65 +110,136 @@ func (d *spAssetDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	pathParamsMap := map[string]string{
-		"service_provider_id": plan.ServiceProviderID.ValueString(),
+	instanceID, err := d.client.OpenScaleInstanceID(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Watson OpenScale instance", err.Error())
+		return
 	}
+
 	builder := core.NewRequestBuilder(core.GET)
-	builder = builder.WithContext(context.Background())
-	_, err = builder.ResolveRequestURL(d.client.Config.URL, `/v1/ml_instances/{service_provider_id}/deployments?datamart_id=00000000-0000-0000-0000-000000000000&limit=10`, pathParamsMap)
+	_, err = builder.ResolveRequestURL(d.client.Config.WOSURL(), `/v1/ml_instances/{service_provider_id}/deployments`, map[string]string{
+		"service_provider_id": plan.ServiceProviderID.ValueString(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to build SP Assets URL", err.Error())
 		return
 	}
+	query := builder.URL.Query()
+	query.Set("datamart_id", instanceID)
+	query.Set("limit", "100")
+	builder.URL.RawQuery = query.Encode()
+
+	// The deployments are returned in pages linked by next.
+	for next := builder.URL; next != nil; {
+		page, err := listSPAssetsPage(ctx, wosClient, next)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Listing SP Assets", "Could not list assets of service provider ID "+plan.ServiceProviderID.ValueString()+", "+err.Error())
+			return
+		}
+
+		for _, resource := range page.Resources {
+			spAsset := spAssetsModel{
+				AssetID:      types.StringValue(resource.Entity.Asset.AssetID),
+				Name:         types.StringValue(resource.Entity.Name),
+				DeploymentRN: types.StringValue(resource.Entity.DeploymentRN),
+				DeploymentID: types.StringValue(resource.Metadata.GUID),
+			}
+			state.SPAssets = append(state.SPAssets, spAsset)
+		}
+
+		next, err = page.nextURL(next)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Listing SP Assets", "Invalid next page of service provider ID "+plan.ServiceProviderID.ValueString()+", "+err.Error())
+			return
+		}
+	}
+
+	state.ID = types.StringValue("placeholder")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+type spAssetsPage struct {
+	Resources []struct {
+		Metadata struct {
+			GUID string `json:"guid"`
+		} `json:"metadata"`
+		Entity struct {
+			Name         string `json:"name"`
+			DeploymentRN string `json:"deployment_rn"`
+			Asset        struct {
+				AssetID string `json:"asset_id"`
+			} `json:"asset"`
+		} `json:"entity"`
+	} `json:"resources"`
+	// Next is the URL of the next page, either as string or as object with
+	// href. It is missing on the last page.
+	Next json.RawMessage `json:"next"`
+}
+
+// nextURL returns the URL of the next page resolved against the URL of the
+// current page, or nil on the last page.
+func (p *spAssetsPage) nextURL(current *url.URL) (*url.URL, error) {
+	if len(p.Next) == 0 || string(p.Next) == "null" {
+		return nil, nil
+	}
+	var href string
+	if err := json.Unmarshal(p.Next, &href); err != nil {
+		var next struct {
+			Href string `json:"href"`
+		}
+		if err := json.Unmarshal(p.Next, &next); err != nil {
+			return nil, err
+		}
+		href = next.Href
+	}
+	if href == "" {
+		return nil, nil
+	}
+	next, err := current.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	if next.String() == current.String() {
+		return nil, nil
+	}
+	return next, nil
+}
+
+func listSPAssetsPage(ctx context.Context, wosClient *watsonopenscalev2.WatsonOpenScaleV2, pageURL *url.URL) (*spAssetsPage, error) {
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	builder.URL = pageURL
+	utils.AddHeaders(builder, wosClient.Service.DefaultHeaders)
 	builder.AddHeader("Content-Type", "application/json")
 	request, err := builder.Build()
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to create URL builder", err.Error())
-		return
+		return nil, err
 	}
 	err = wosClient.Service.Options.Authenticator.Authenticate(request)
 	if err != nil {
-		resp.Diagnostics.AddError("Authentication error", err.Error())
-		return
+		return nil, err
 	}
 	response, err := wosClient.Service.Client.Do(request)
 	if err != nil {
-		resp.Diagnostics.AddError("Error received from HTTP client", err.Error())
-		return
+		return nil, err
 	}
 	defer response.Body.Close()
 	content, err := io.ReadAll(response.Body)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading from HTTP response", err.Error())
-		return
+		return nil, err
 	}
-	var jsonResponse map[string]interface{}
-	err = json.Unmarshal(content, &jsonResponse)
-	if err != nil {
-		resp.Diagnostics.AddError("Error parsing response json", err.Error())
-		return
-	}
-
-	resources := jsonResponse["resources"].([]interface{})
-	for _, resource := range resources {
-		metadata := resource.(map[string]interface{})["metadata"].(map[string]interface{})
-		entity := resource.(map[string]interface{})["entity"].(map[string]interface{})
-		asset := entity["asset"].(map[string]interface{})
-
-		spAsset := spAssetsModel{
-			AssetID:      types.StringValue(asset["asset_id"].(string)),
-			Name:         types.StringValue(entity["name"].(string)),
-			DeploymentRN: types.StringValue(entity["deployment_rn"].(string)),
-			DeploymentID: types.StringValue(metadata["guid"].(string)),
-		}
-		state.SPAssets = append(state.SPAssets, spAsset)
+	if err := utils.BodyError(response.StatusCode, content); err != nil {
+		return nil, err
 	}
 
-	state.ID = types.StringValue("placeholder")
-
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	var page spAssetsPage
+	if err := json.Unmarshal(content, &page); err != nil {
+		return nil, err
 	}
+	return &page, nil
 }
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &spaceDataSource{}
+	_ datasource.DataSourceWithConfigure = &spaceDataSource{}
+)
+
+func NewSpaceDataSource() datasource.DataSource {
+	return &spaceDataSource{}
+}
+
+type spaceDataSource struct {
+	client *client.Client
+}
+
+type spaceDataSourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Tags        []types.String `tfsdk:"tags"`
+	SpaceName   types.String   `tfsdk:"space_name"`
+	SpaceTags   []types.String `tfsdk:"space_tags"`
+	Member      types.String   `tfsdk:"member"`
+	ComputeType types.String   `tfsdk:"compute_type"`
+	Stage       types.String   `tfsdk:"stage"`
+	Production  types.Bool     `tfsdk:"production"`
+	Status      types.String   `tfsdk:"status"`
+	CreatedAt   types.String   `tfsdk:"created_at"`
+}
+
+func (d *spaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *spaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (d *spaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single deployment space by name, tags, member or compute type. Fails if no space or more than one space matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for space.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of space to look up. See space_name for the name of the space found.",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Tags of space to look up. See space_tags for the tags of the space found.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"space_name": schema.StringAttribute{
+				Description: "Name of space.",
+				Computed:    true,
+			},
+			"space_tags": schema.ListAttribute{
+				Description: "Tags of space.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"member": schema.StringAttribute{
+				Description: "IAM ID of a member of space to look up.",
+				Optional:    true,
+			},
+			"compute_type": schema.StringAttribute{
+				Description: "Compute instance type of space to look up, e.g. machine_learning or openscale.",
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of space.",
+				Computed:    true,
+			},
+			"stage": schema.StringAttribute{
+				Description: "Stage name of space.",
+				Computed:    true,
+			},
+			"production": schema.BoolAttribute{
+				Description: "Whether the space is a production space.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of space.",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation time of space.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *spaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state spaceDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := d.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	spaces, err := listSpaces(ctx, spaceClient, state.Name.ValueString(), utils.ConvertString(state.Tags), state.Member.ValueString(), state.ComputeType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Spaces", "Could not list spaces, unexpected error: "+err.Error())
+		return
+	}
+
+	if len(spaces) == 0 {
+		resp.Diagnostics.AddError("Space Not Found", "No space matches the given filters.")
+		return
+	}
+	if len(spaces) > 1 {
+		ids := make([]string, len(spaces))
+		for i, v := range spaces {
+			ids[i] = *v.Metadata.ID
+		}
+		resp.Diagnostics.AddError("Multiple Spaces Found", fmt.Sprintf("%d spaces match the given filters: %v. Use more specific filters or the ibmcpd_spaces data source.", len(spaces), ids))
+		return
+	}
+
+	space := newSpacesModel(spaces[0])
+	state.ID = space.ID
+	state.SpaceName = space.Name
+	state.Description = space.Description
+	state.SpaceTags = space.Tags
+	state.Stage = space.Stage
+	state.Production = space.Production
+	state.Status = space.Status
+	state.CreatedAt = space.CreatedAt
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &spacesDataSource{}
+	_ datasource.DataSourceWithConfigure = &spacesDataSource{}
+)
+
+func NewSpacesDataSource() datasource.DataSource {
+	return &spacesDataSource{}
+}
+
+type spacesDataSource struct {
+	client *client.Client
+}
+
+type spacesDataSourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Tags        []types.String `tfsdk:"tags"`
+	Member      types.String   `tfsdk:"member"`
+	ComputeType types.String   `tfsdk:"compute_type"`
+	Spaces      []spacesModel  `tfsdk:"spaces"`
+}
+
+type spacesModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Tags        []types.String `tfsdk:"tags"`
+	Stage       types.String   `tfsdk:"stage"`
+	Production  types.Bool     `tfsdk:"production"`
+	Status      types.String   `tfsdk:"status"`
+	CreatedAt   types.String   `tfsdk:"created_at"`
+}
+
+func (d *spacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(*client.Client)
+}
+
+func (d *spacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spaces"
+}
+
+func (d *spacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists deployment spaces, optionally filtered by name, tags, member or compute type.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Placeholder identifier attribute.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Only include spaces with this name.",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Only include spaces with these tags.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"member": schema.StringAttribute{
+				Description: "Only include spaces where the user with this IAM ID is a member.",
+				Optional:    true,
+			},
+			"compute_type": schema.StringAttribute{
+				Description: "Only include spaces with a compute instance of this type, e.g. machine_learning or openscale.",
+				Optional:    true,
+			},
+			"spaces": schema.ListNestedAttribute{
+				Description: "List of spaces.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Identifier for space.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of space.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of space.",
+							Computed:    true,
+						},
+						"tags": schema.ListAttribute{
+							Description: "User-defined tags of space.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"stage": schema.StringAttribute{
+							Description: "Stage name of space.",
+							Computed:    true,
+						},
+						"production": schema.BoolAttribute{
+							Description: "Whether the space is a production space.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of space.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Creation time of space.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *spacesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state spacesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := d.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	spaces, err := listSpaces(ctx, spaceClient, state.Name.ValueString(), utils.ConvertString(state.Tags), state.Member.ValueString(), state.ComputeType.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Spaces", "Could not list spaces, unexpected error: "+err.Error())
+		return
+	}
+
+	state.Spaces = make([]spacesModel, len(spaces))
+	for i, v := range spaces {
+		state.Spaces[i] = newSpacesModel(v)
+	}
+
+	state.ID = types.StringValue("placeholder")
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// listSpaces walks all pages of the spaces API. Name, tags and member are
+// filtered server side, compute type is not supported by the API and is
+// filtered on the returned compute instances.
+func listSpaces(ctx context.Context, spaceClient *spacev2.SpaceV2, name string, tags []string, member string, computeType string) ([]spacev2.SpaceResource, error) {
+	pager, err := spaceClient.NewSpacesListPager(&spacev2.SpacesListOptions{
+		Name:   utils.If(name != "", core.StringPtr(name), nil),
+		Tags:   utils.If(len(tags) > 0, core.StringPtr(strings.Join(tags, ",")), nil),
+		Member: utils.If(member != "", core.StringPtr(member), nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allSpaces, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if computeType == "" {
+		return allSpaces, nil
+	}
+
+	var spaces []spacev2.SpaceResource
+	for _, space := range allSpaces {
+		for _, compute := range space.Entity.Compute {
+			if compute.Type != nil && *compute.Type == computeType {
+				spaces = append(spaces, space)
+				break
+			}
+		}
+	}
+	return spaces, nil
+}
+
+func newSpacesModel(space spacev2.SpaceResource) spacesModel {
+	model := spacesModel{
+		ID:          types.StringValue(*space.Metadata.ID),
+		Name:        types.StringValue(*space.Entity.Name),
+		Description: utils.StringValueOrNull(space.Entity.Description),
+		Stage:       types.StringNull(),
+		Production:  types.BoolNull(),
+		Status:      types.StringNull(),
+		CreatedAt:   types.StringNull(),
+	}
+	for _, v := range space.Entity.Tags {
+		model.Tags = append(model.Tags, types.StringValue(v))
+	}
+	if space.Entity.Stage != nil {
+		model.Stage = utils.StringValueOrNull(space.Entity.Stage.Name)
+		if space.Entity.Stage.Production != nil {
+			model.Production = types.BoolValue(*space.Entity.Stage.Production)
+		}
+	}
+	if space.Entity.Status != nil {
+		model.Status = utils.StringValueOrNull(space.Entity.Status.State)
+	}
+	if space.Metadata.CreatedAt != nil {
+		model.CreatedAt = types.StringValue(space.Metadata.CreatedAt.String())
+	}
+	return model
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checksumFromFile plans a computed checksum attribute as the SHA-256 of the
+// local file in the given path attribute, so that a change of the file content
+// shows up in the plan. A checksum set in the configuration is left as is, and
+// a file that no longer exists, e.g. after cleanup_local_file, keeps the
+// checksum in state.
+func checksumFromFile(pathAttribute string) planmodifier.String {
+	return checksumFromFileModifier{pathAttribute: pathAttribute}
+}
+
+// checksumOfOutputFile is checksumFromFile for a file written by the
+// resource, e.g. a downloaded archive. A missing file plans the checksum as
+// unknown, so that together with RequiresReplace a missing or modified file
+// is written again.
+func checksumOfOutputFile(pathAttribute string) planmodifier.String {
+	return checksumFromFileModifier{pathAttribute: pathAttribute, unknownIfMissing: true}
+}
+
+type checksumFromFileModifier struct {
+	pathAttribute    string
+	unknownIfMissing bool
+}
+
+func (m checksumFromFileModifier) Description(_ context.Context) string {
+	return "Plans the value as the SHA-256 checksum of the file in " + m.pathAttribute + "."
+}
+
+func (m checksumFromFileModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m checksumFromFileModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var filePath types.String
+	diags := req.Plan.GetAttribute(ctx, path.Root(m.pathAttribute), &filePath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || filePath.IsUnknown() || filePath.IsNull() {
+		return
+	}
+
+	// A missing file on create is reported when the resource is applied.
+	checksum, err := utils.FileChecksum(filePath.ValueString())
+	if err != nil {
+		if m.unknownIfMissing {
+			resp.PlanValue = types.StringUnknown()
+		} else if !req.StateValue.IsNull() {
+			resp.PlanValue = req.StateValue
+		}
+		return
+	}
+	resp.PlanValue = types.StringValue(checksum)
+}
+
+// plannedFileChecksum returns the checksum to store in state for the file and
+// whether its content differs from the prior checksum. The planned checksum is
+// used when known, otherwise the file is read.
+func plannedFileChecksum(filePath string, planned types.String, prior types.String) (string, bool, error) {
+	if !planned.IsUnknown() && !planned.IsNull() {
+		return planned.ValueString(), planned.ValueString() != prior.ValueString(), nil
+	}
+	checksum, err := utils.FileChecksum(filePath)
+	if err != nil {
+		if os.IsNotExist(err) && prior.ValueString() != "" {
+			return prior.ValueString(), false, nil
+		}
+		return "", false, err
+	}
+	return checksum, checksum != prior.ValueString(), nil
+}
+
+// removeLocalFile deletes a local file after it was uploaded, when
+// cleanup_local_file is enabled.
+func removeLocalFile(cleanup types.Bool, filePath string) {
+	if !cleanup.ValueBool() || filePath == "" {
+		return
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		os.Remove(filePath)
+	}
+}
+
+// useStateForUnknownUnlessChanged is UseStateForUnknown for a computed
+// attribute that only changes together with the given attributes, e.g. the
+// serving URLs of a deployment. If values is set, the prior value is only
+// used if it is one of them.
+func useStateForUnknownUnlessChanged(values []string, paths ...path.Path) useStateForUnknownModifier {
+	return useStateForUnknownModifier{values: values, paths: paths}
+}
+
+type useStateForUnknownModifier struct {
+	values []string
+	paths  []path.Path
+}
+
+func (m useStateForUnknownModifier) Description(_ context.Context) string {
+	return "Uses the prior value while the attributes it depends on do not change."
+}
+
+func (m useStateForUnknownModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if len(m.values) > 0 && !utils.Contains(m.values, req.StateValue.ValueString()) {
+		return
+	}
+	if m.changed(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+func (m useStateForUnknownModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if m.changed(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+func (m useStateForUnknownModifier) changed(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) bool {
+	for _, p := range m.paths {
+		var planValue, stateValue attr.Value
+		diags.Append(plan.GetAttribute(ctx, p, &planValue)...)
+		diags.Append(state.GetAttribute(ctx, p, &stateValue)...)
+		if diags.HasError() || !planValue.Equal(stateValue) {
+			return true
+		}
+	}
+	return false
+}
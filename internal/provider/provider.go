@@ -2,16 +2,25 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"terraform-provider-ibmcpd/internal/go-sdk/client"
 	"terraform-provider-ibmcpd/internal/utils"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -28,6 +37,40 @@ type ProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	ApiKey   types.String `tfsdk:"api_key"`
+
+	BearerToken types.String `tfsdk:"bearer_token"`
+	Profile     types.String `tfsdk:"profile"`
+
+	AuthType        types.String `tfsdk:"auth_type"`
+	AuthURL         types.String `tfsdk:"auth_url"`
+	CRTokenFilename types.String `tfsdk:"cr_token_filename"`
+	IAMProfileName  types.String `tfsdk:"iam_profile_name"`
+	IAMProfileID    types.String `tfsdk:"iam_profile_id"`
+
+	Platform              types.String `tfsdk:"platform"`
+	Region                types.String `tfsdk:"region"`
+	WMLURL                types.String `tfsdk:"wml_url"`
+	WOSURL                types.String `tfsdk:"wos_url"`
+	SpacesURL             types.String `tfsdk:"spaces_url"`
+	DataplatformURL       types.String `tfsdk:"dataplatform_url"`
+	ResourceControllerURL types.String `tfsdk:"resource_controller_url"`
+	OpenScaleInstanceID   types.String `tfsdk:"openscale_instance_id"`
+	WMLInstanceID         types.String `tfsdk:"wml_instance_id"`
+
+	MaxRetries       types.Int64 `tfsdk:"max_retries"`
+	MaxRetryInterval types.Int64 `tfsdk:"max_retry_interval"`
+
+	ProxyURL       types.String `tfsdk:"proxy_url"`
+	NoProxy        types.String `tfsdk:"no_proxy"`
+	DefaultHeaders types.Map    `tfsdk:"default_headers"`
+
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
 }
 
 func New() provider.Provider {
@@ -43,20 +86,147 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 		Description: "Provider to manage IBM Cloud Pak for Data resources.",
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
-				Description: "URL for IBM Cloud Pak for Data.",
-				Required:    true,
+				Description: "URL for IBM Cloud Pak for Data. Required for the cpd platform. Can also be set with IBMCPD_URL or in the profile.",
+				Optional:    true,
 			},
 			"username": schema.StringAttribute{
-				Description: "Username for IBM Cloud Pak for Data.",
+				Description: "Username for IBM Cloud Pak for Data. Can also be set with IBMCPD_USERNAME or in the profile.",
 				Optional:    true,
 			},
 			"password": schema.StringAttribute{
-				Description: "Password for IBM Cloud Pak for Data.",
+				Description: "Password for IBM Cloud Pak for Data. Can also be set with IBMCPD_PASSWORD or in the profile.",
 				Optional:    true,
 				Sensitive:   true,
 			},
 			"api_key": schema.StringAttribute{
-				Description: "API key for IBM Cloud Pak for Data.",
+				Description: "API key for IBM Cloud Pak for Data. Can also be set with IBMCPD_API_KEY or in the profile.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"bearer_token": schema.StringAttribute{
+				Description: "Bearer token for IBM Cloud Pak for Data, used instead of username and password or API key. Can also be set with IBMCPD_BEARER_TOKEN or in the profile.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"auth_type": schema.StringAttribute{
+				Description: "Authentication type, one of cp4d_password (username and password), cp4d_apikey (username and api_key), iam (api_key), bearer_token (bearer_token), container (compute resource token with iam_profile_name or iam_profile_id) or zen_apikey (username and platform api_key). Can also be set with IBMCPD_AUTH_TYPE or in the profile. Defaults to bearer_token if a bearer token is set, iam for the cloud platform and cp4d_apikey or cp4d_password otherwise.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(utils.AUTH_TYPES...),
+				},
+			},
+			"auth_url": schema.StringAttribute{
+				Description: "URL of the token service, e.g. a private IAM endpoint. Defaults to IAM for iam and container and to <url>/icp4d-api for cp4d_password and cp4d_apikey. Can also be set with IBMCPD_AUTH_URL or in the profile.",
+				Optional:    true,
+			},
+			"cr_token_filename": schema.StringAttribute{
+				Description: "File containing the compute resource token for container. Defaults to /var/run/secrets/tokens/vault-token. Can also be set with IBMCPD_CR_TOKEN_FILENAME or in the profile.",
+				Optional:    true,
+			},
+			"iam_profile_name": schema.StringAttribute{
+				Description: "Name of the trusted profile for container. Can also be set with IBMCPD_IAM_PROFILE_NAME or in the profile.",
+				Optional:    true,
+			},
+			"iam_profile_id": schema.StringAttribute{
+				Description: "ID of the trusted profile for container. Can also be set with IBMCPD_IAM_PROFILE_ID or in the profile.",
+				Optional:    true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Name of the profile in the credentials file ~/.ibmcpd/credentials (or IBMCPD_CREDENTIALS_FILE) to read unset attributes from. Can also be set with IBMCPD_PROFILE. Defaults to the default profile if present.",
+				Optional:    true,
+			},
+			"platform": schema.StringAttribute{
+				Description: "Platform hosting the services, cpd for IBM Cloud Pak for Data or cloud for IBM Cloud. Can also be set with IBMCPD_PLATFORM or in the profile. Defaults to cloud for IBM Cloud URLs and cpd otherwise.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.Platforms...),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description: "IBM Cloud region of the services for the cloud platform. Can also be set with IBMCPD_REGION or in the profile. Defaults to the region of url, e.g. eu-de for https://eu-de.ml.cloud.ibm.com, and to " + client.DefaultRegion + " otherwise. Must match the region of url if both are set.",
+				Optional:    true,
+			},
+			"wml_url": schema.StringAttribute{
+				Description: "URL of Watson Machine Learning, including the /ml path. Defaults to the endpoint of the platform and region. Can also be set with IBMCPD_WML_URL or in the profile.",
+				Optional:    true,
+			},
+			"wos_url": schema.StringAttribute{
+				Description: "URL of Watson OpenScale, without the /openscale path. Defaults to the endpoint of the platform and region. Can also be set with IBMCPD_WOS_URL or in the profile.",
+				Optional:    true,
+			},
+			"spaces_url": schema.StringAttribute{
+				Description: "URL of the API serving deployment spaces and platform jobs. Defaults to the endpoint of the platform and region. Can also be set with IBMCPD_SPACES_URL or in the profile.",
+				Optional:    true,
+			},
+			"dataplatform_url": schema.StringAttribute{
+				Description: "URL of the data platform, used to promote assets. Defaults to the endpoint of the platform and region. Can also be set with IBMCPD_DATAPLATFORM_URL or in the profile.",
+				Optional:    true,
+			},
+			"resource_controller_url": schema.StringAttribute{
+				Description: "URL of the IBM Cloud resource controller, used to look up Watson OpenScale instances on the cloud platform, e.g. https://private.resource-controller.cloud.ibm.com for the private endpoint. Defaults to " + utils.RESOURCE_CONTROLLER_URL + ". Can also be set with IBMCPD_RESOURCE_CONTROLLER_URL or in the profile.",
+				Optional:    true,
+			},
+			"openscale_instance_id": schema.StringAttribute{
+				Description: "ID of the Watson OpenScale service instance, see the ibmcpd_openscale_instances data source. Required for the cloud platform if the account has more than one active instance in the region. Defaults to the only instance of the account for the cloud platform and to the default instance otherwise. Can also be set with IBMCPD_OPENSCALE_INSTANCE_ID or in the profile.",
+				Optional:    true,
+			},
+			"wml_instance_id": schema.StringAttribute{
+				Description: "ID of the Watson Machine Learning service instance, sent as ML-Instance-ID header. Can also be set with IBMCPD_WML_INSTANCE_ID, WATSON_MACHINE_LEARNING_INSTANCE_ID or in the profile.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of retries of requests failing with status code 429 or 5xx or with a connection error. 0 disables retries. Defaults to " + strconv.Itoa(client.DefaultMaxRetries) + ".",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_retry_interval": schema.Int64Attribute{
+				Description: "Maximum wait time between retries in seconds. The wait time of a Retry-After header is used if present, otherwise it grows exponentially up to this value. Defaults to " + strconv.Itoa(int(client.DefaultMaxRetryInterval.Seconds())) + ".",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the HTTP(S) proxy for all requests, e.g. http://proxy.example.com:3128. Defaults to HTTPS_PROXY or HTTP_PROXY. Can also be set with IBMCPD_PROXY_URL or in the profile.",
+				Optional:    true,
+			},
+			"no_proxy": schema.StringAttribute{
+				Description: "Comma separated hosts, domains and CIDR ranges reached without proxy, in the format of NO_PROXY. Defaults to NO_PROXY. Can also be set with IBMCPD_NO_PROXY or in the profile.",
+				Optional:    true,
+			},
+			"default_headers": schema.MapAttribute{
+				Description: "HTTP headers sent with every API request, e.g. a tenant header required by a gateway.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the server certificate. Defaults to false.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path of a PEM encoded CA bundle used in addition to the system CAs to verify the server certificate.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA bundle used in addition to the system CAs to verify the server certificate.",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path of a PEM encoded client certificate for mutual TLS.",
+				Optional:    true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate for mutual TLS.",
+				Optional:    true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path of the PEM encoded private key of the client certificate.",
+				Optional:    true,
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate.",
 				Optional:    true,
 				Sensitive:   true,
 			},
@@ -66,9 +236,17 @@ func (p *Provider) Schema(_ context.Context, _ provider.SchemaRequest, resp *pro
 
 func (p *Provider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
 	return []provider.ConfigValidator{
-		providervalidator.AtLeastOneOf(
-			path.MatchRoot("password"),
-			path.MatchRoot("api_key"),
+		providervalidator.Conflicting(
+			path.MatchRoot("ca_cert_file"),
+			path.MatchRoot("ca_cert_pem"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_cert_file"),
+			path.MatchRoot("client_cert_pem"),
+		),
+		providervalidator.Conflicting(
+			path.MatchRoot("client_key_file"),
+			path.MatchRoot("client_key_pem"),
 		),
 	}
 }
@@ -89,18 +267,53 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 		return
 	}
 
-	url := config.URL.ValueString()
-	username := config.Username.ValueString()
-	password := config.Password.ValueString()
-	apiKey := config.ApiKey.ValueString()
+	// Attributes set in the configuration take precedence over environment
+	// variables, which take precedence over the profile.
+	profileName := configValue(config.Profile, "IBMCPD_PROFILE", nil, "")
+	credentialsFile := utils.DefaultCredentialsFile()
+	profile, err := utils.LoadProfile(credentialsFile, utils.If(profileName != "", profileName, utils.DEFAULT_PROFILE))
+	if err != nil && (profileName != "" || !errors.Is(err, os.ErrNotExist)) {
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "Unable to load profile", "Unable to load profile from "+credentialsFile+": "+err.Error())
+		return
+	}
+
+	url := strings.TrimSuffix(configValue(config.URL, "IBMCPD_URL", profile, "url"), "/")
+	platform := configValue(config.Platform, "IBMCPD_PLATFORM", profile, "platform")
+	if platform == "" {
+		platform = utils.If(strings.Contains(url, "cloud.ibm.com"), client.PlatformCloud, client.PlatformCPD)
+	}
+
+	authConfig := utils.AuthConfig{
+		Type:            configValue(config.AuthType, "IBMCPD_AUTH_TYPE", profile, "auth_type"),
+		URL:             url,
+		PublicCloud:     platform == client.PlatformCloud,
+		AuthURL:         configValue(config.AuthURL, "IBMCPD_AUTH_URL", profile, "auth_url"),
+		Username:        configValue(config.Username, "IBMCPD_USERNAME", profile, "username"),
+		Password:        configValue(config.Password, "IBMCPD_PASSWORD", profile, "password"),
+		ApiKey:          configValue(config.ApiKey, "IBMCPD_API_KEY", profile, "api_key"),
+		BearerToken:     configValue(config.BearerToken, "IBMCPD_BEARER_TOKEN", profile, "bearer_token"),
+		CRTokenFilename: configValue(config.CRTokenFilename, "IBMCPD_CR_TOKEN_FILENAME", profile, "cr_token_filename"),
+		IAMProfileName:  configValue(config.IAMProfileName, "IBMCPD_IAM_PROFILE_NAME", profile, "iam_profile_name"),
+		IAMProfileID:    configValue(config.IAMProfileID, "IBMCPD_IAM_PROFILE_ID", profile, "iam_profile_id"),
+	}
+	authType := utils.If(authConfig.Type != "", authConfig.Type, authConfig.DefaultAuthType())
+
+	if !utils.Contains(client.Platforms, platform) {
+		resp.Diagnostics.AddAttributeError(path.Root("platform"), "Invalid platform", "Unsupported platform "+platform+", expected one of "+strings.Join(client.Platforms, ", ")+".")
+	}
+
+	if url == "" && platform == client.PlatformCPD {
+		resp.Diagnostics.AddAttributeError(path.Root("url"), "Missing url", "Unable to create API client with missing URL. Set url, IBMCPD_URL or url in the profile.")
+	}
 
-	if url == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("url"), "Missing url", "Unable to create API client with missing URL.")
+	if !utils.Contains(utils.AUTH_TYPES, authType) {
+		resp.Diagnostics.AddAttributeError(path.Root("auth_type"), "Invalid auth_type", "Unsupported authentication type "+authType+", expected one of "+strings.Join(utils.AUTH_TYPES, ", ")+".")
 	}
 
-	if password == "" && apiKey == "" {
-		resp.Diagnostics.AddAttributeError(path.Root("password"), "Missing password", "Unable to create API client with missing password or API key.")
-		resp.Diagnostics.AddAttributeError(path.Root("api_key"), "Missing API key", "Unable to create API client with missing password or API key.")
+	for _, setting := range requiredAuthSettings(authType, &authConfig) {
+		if setting.value == "" {
+			resp.Diagnostics.AddAttributeError(path.Root(setting.name), "Missing "+setting.name, "Unable to create API client, "+setting.name+" is required for auth_type "+authType+".")
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -108,21 +321,79 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	}
 
 	ctx = tflog.SetField(ctx, "ibmcpd_url", url)
-	ctx = tflog.SetField(ctx, "ibmcpd_username", username)
-	ctx = tflog.SetField(ctx, "ibmcpd_password", password)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ibmcpd_password")
+	ctx = tflog.SetField(ctx, "ibmcpd_platform", platform)
+	ctx = tflog.SetField(ctx, "ibmcpd_username", authConfig.Username)
+	ctx = tflog.SetField(ctx, "ibmcpd_auth_type", authType)
+	ctx = tflog.SetField(ctx, "ibmcpd_profile", profileName)
 
 	tflog.Debug(ctx, "Creating IBM CPD client")
 
 	// auth, err := core.NewCloudPakForDataAuthenticatorUsingAPIKey(fmt.Sprintf("%s/icp4d-api", url), username, apiKey, true, map[string]string{})
 	// auth, err := core.NewIamAuthenticator(ApiKey, IamUrl, "bx", "bx", false, map[string]string{})
 
-	auth, err := utils.GetAuthenticator(url, username, password, apiKey)
+	clientConfig := &client.Config{
+		URL:      url,
+		Platform: platform,
+		Region:   configValue(config.Region, "IBMCPD_REGION", profile, "region"),
+		Endpoints: client.Endpoints{
+			WMLURL:                configValue(config.WMLURL, "IBMCPD_WML_URL", profile, "wml_url"),
+			WOSURL:                configValue(config.WOSURL, "IBMCPD_WOS_URL", profile, "wos_url"),
+			SpacesURL:             configValue(config.SpacesURL, "IBMCPD_SPACES_URL", profile, "spaces_url"),
+			DataplatformURL:       configValue(config.DataplatformURL, "IBMCPD_DATAPLATFORM_URL", profile, "dataplatform_url"),
+			ResourceControllerURL: configValue(config.ResourceControllerURL, "IBMCPD_RESOURCE_CONTROLLER_URL", profile, "resource_controller_url"),
+			OpenScaleInstanceID:   configValue(config.OpenScaleInstanceID, "IBMCPD_OPENSCALE_INSTANCE_ID", profile, "openscale_instance_id"),
+		},
+		MaxRetries:       client.DefaultMaxRetries,
+		MaxRetryInterval: client.DefaultMaxRetryInterval,
+		ProxyURL:         configValue(config.ProxyURL, "IBMCPD_PROXY_URL", profile, "proxy_url"),
+		NoProxy:          configValue(config.NoProxy, "IBMCPD_NO_PROXY", profile, "no_proxy"),
+		DefaultHeaders:   http.Header{},
+		WMLInstanceID:    configValue(config.WMLInstanceID, "IBMCPD_WML_INSTANCE_ID", profile, "wml_instance_id"),
+		LogContext:       ctx,
+		TLS: client.TLSConfig{
+			InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+			CACertFile:         config.CACertFile.ValueString(),
+			CACertPEM:          config.CACertPEM.ValueString(),
+			ClientCertFile:     config.ClientCertFile.ValueString(),
+			ClientCertPEM:      config.ClientCertPEM.ValueString(),
+			ClientKeyFile:      config.ClientKeyFile.ValueString(),
+			ClientKeyPEM:       config.ClientKeyPEM.ValueString(),
+		},
+	}
+	if region := clientConfig.URLRegion(); clientConfig.IsPublicCloud() && clientConfig.Region != "" && region != "" && region != clientConfig.Region {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), "Conflicting region", "Region "+clientConfig.Region+" does not match region "+region+" of URL "+url+". Set only one of them or make them consistent.")
+		return
+	}
+	if clientConfig.WMLInstanceID == "" {
+		clientConfig.WMLInstanceID = os.Getenv("WATSON_MACHINE_LEARNING_INSTANCE_ID")
+	}
+	var defaultHeaders map[string]string
+	resp.Diagnostics.Append(config.DefaultHeaders.ElementsAs(ctx, &defaultHeaders, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for name, value := range defaultHeaders {
+		clientConfig.DefaultHeaders.Set(name, value)
+	}
+	if !config.MaxRetries.IsNull() {
+		clientConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.MaxRetryInterval.IsNull() {
+		clientConfig.MaxRetryInterval = time.Duration(config.MaxRetryInterval.ValueInt64()) * time.Second
+	}
+	httpClient, err := clientConfig.HTTPClient()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure HTTP client", "Error: "+err.Error())
+		return
+	}
+
+	authConfig.HTTPClient = httpClient
+	auth, err := utils.GetAuthenticator(authConfig)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to authenticate IBM CPD credentials", "Error: "+err.Error())
 		return
 	}
-	client, err := client.NewClient(auth, &client.Config{URL: url})
+	client, err := client.NewClient(auth, clientConfig)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create Client API", "Error: "+err.Error())
 		return
@@ -134,6 +405,40 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	tflog.Info(ctx, "Configured IBM CPD client", map[string]interface{}{"success": true})
 }
 
+type authSetting struct {
+	name  string
+	value string
+}
+
+// requiredAuthSettings returns the settings that authType requires. The
+// trusted profile of container is validated by the authenticator, since
+// either the name or the ID is required.
+func requiredAuthSettings(authType string, config *utils.AuthConfig) []authSetting {
+	switch authType {
+	case utils.AUTH_TYPE_CP4D_PASSWORD:
+		return []authSetting{{"username", config.Username}, {"password", config.Password}}
+	case utils.AUTH_TYPE_CP4D_APIKEY, utils.AUTH_TYPE_ZEN_APIKEY:
+		return []authSetting{{"username", config.Username}, {"api_key", config.ApiKey}}
+	case utils.AUTH_TYPE_IAM:
+		return []authSetting{{"api_key", config.ApiKey}}
+	case utils.AUTH_TYPE_BEARER_TOKEN:
+		return []authSetting{{"bearer_token", config.BearerToken}}
+	}
+	return nil
+}
+
+// configValue returns the value of an attribute, falling back to the
+// environment variable env and then to key in profile.
+func configValue(value types.String, env string, profile map[string]string, key string) string {
+	if value.ValueString() != "" {
+		return value.ValueString()
+	}
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return profile[key]
+}
+
 func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewModelResource,
@@ -142,11 +447,22 @@ func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
 		NewMonitorInstanceResource,
 		NewRecordResource,
 		NewServiceProviderResource,
+		NewSpaceResource,
+		NewSpaceMemberResource,
+		NewSpaceExportResource,
+		NewSpaceImportResource,
+		NewFunctionResource,
+		NewDeploymentJobDefinitionResource,
+		NewDeploymentJobResource,
 	}
 }
 
 func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSPAssetDataSource,
+		NewSpacesDataSource,
+		NewSpaceDataSource,
+		NewOpenScaleInstancesDataSource,
+		NewPredictionDataSource,
 	}
 }
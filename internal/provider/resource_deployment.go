@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -12,22 +13,41 @@ import (
 	"terraform-provider-ibmcpd/internal/utils"
 
 	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const NUM_TRIES_DEPLOYMENT = 10
-const TIMEOUT_DEPLOYMENT = 5 * time.Second
+const DEFAULT_TIMEOUT_DEPLOYMENT = 20 * time.Minute
+
+var rShinyAuthentications = []string{
+	watsonmachinelearningv4.DeploymentEntityRequestRShiny_Authentication_AnyValidUser,
+	watsonmachinelearningv4.DeploymentEntityRequestRShiny_Authentication_AnyoneWithURL,
+	watsonmachinelearningv4.DeploymentEntityRequestRShiny_Authentication_MembersOfDeploymentSpace,
+}
+
+const (
+	DEPLOYMENT_TYPE_ONLINE  = "online"
+	DEPLOYMENT_TYPE_BATCH   = "batch"
+	DEPLOYMENT_TYPE_R_SHINY = "r_shiny"
+)
 
 var (
-	_ resource.Resource                = &deploymentResource{}
-	_ resource.ResourceWithConfigure   = &deploymentResource{}
-	_ resource.ResourceWithImportState = &deploymentResource{}
+	_ resource.Resource                     = &deploymentResource{}
+	_ resource.ResourceWithConfigure        = &deploymentResource{}
+	_ resource.ResourceWithImportState      = &deploymentResource{}
+	_ resource.ResourceWithConfigValidators = &deploymentResource{}
 )
 
 type deploymentResource struct {
@@ -39,10 +59,58 @@ type deploymentResourceModel struct {
 	Name       types.String `tfsdk:"name"`
 	ServingUrl types.String `tfsdk:"serving_url"`
 	Asset      types.String `tfsdk:"asset"`
+	AssetRev   types.String `tfsdk:"asset_rev"`
 	SpaceID    types.String `tfsdk:"space_id"`
 	Online     types.Bool   `tfsdk:"online"`
 	Batch      types.Bool   `tfsdk:"batch"`
 	URL        types.String `tfsdk:"url"`
+
+	Description                 types.String                      `tfsdk:"description"`
+	Tags                        []types.String                    `tfsdk:"tags"`
+	Custom                      map[string]types.String           `tfsdk:"custom"`
+	HardwareSpec                *deploymentHardwareSpecModel      `tfsdk:"hardware_spec"`
+	HybridPipelineHardwareSpecs []hybridPipelineHardwareSpecModel `tfsdk:"hybrid_pipeline_hardware_specs"`
+	Copies                      types.Int64                       `tfsdk:"copies"`
+
+	RShiny *rShinyModel `tfsdk:"r_shiny"`
+	AppURL types.String `tfsdk:"app_url"`
+
+	Type          types.String   `tfsdk:"type"`
+	Status        types.String   `tfsdk:"status"`
+	StatusMessage types.String   `tfsdk:"status_message"`
+	ServingURLs   []types.String `tfsdk:"serving_urls"`
+
+	Validation *deploymentValidationModel `tfsdk:"validation"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type deploymentHardwareSpecModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	NumNodes types.Int64  `tfsdk:"num_nodes"`
+}
+
+type deploymentValidationModel struct {
+	PayloadFile    types.String   `tfsdk:"payload_file"`
+	ExpectedFields []types.String `tfsdk:"expected_fields"`
+	MaxLatencyMs   types.Int64    `tfsdk:"max_latency_ms"`
+	Rollback       types.Bool     `tfsdk:"rollback"`
+}
+
+type rShinyModel struct {
+	Authentication types.String           `tfsdk:"authentication"`
+	Parameters     *rShinyParametersModel `tfsdk:"parameters"`
+}
+
+type rShinyParametersModel struct {
+	ServingName     types.String `tfsdk:"serving_name"`
+	CodePackagePath types.String `tfsdk:"code_package_path"`
+}
+
+type hybridPipelineHardwareSpecModel struct {
+	NodeRuntimeID types.String                `tfsdk:"node_runtime_id"`
+	HardwareSpec  deploymentHardwareSpecModel `tfsdk:"hardware_spec"`
 }
 
 func NewDeploymentResource() resource.Resource {
@@ -60,7 +128,11 @@ func (r *deploymentResource) Metadata(_ context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_deployment"
 }
 
-func (r *deploymentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+// servingPaths are the attributes that change the serving URLs of a
+// deployment.
+var servingPaths = []path.Path{path.Root("serving_url"), path.Root("r_shiny")}
+
+func (r *deploymentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -78,22 +150,187 @@ func (r *deploymentResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"asset": schema.StringAttribute{
 				Optional: true,
 			},
+			"asset_rev": schema.StringAttribute{
+				Description: "Revision of asset to deploy. Change it to roll the deployment forward or back to another revision.",
+				Optional:    true,
+			},
 			"space_id": schema.StringAttribute{
 				Required: true,
 			},
 			"online": schema.BoolAttribute{
-				Optional: true,
+				Description: "Creates an online deployment. Exactly one of online, batch or r_shiny must be set. Changing it replaces the deployment.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(requiresReplaceIfModeChanged, "Replaces the deployment if the deployment type changes.", "Replaces the deployment if the deployment type changes."),
+				},
 			},
 			"batch": schema.BoolAttribute{
-				Optional: true,
+				Description: "Creates a batch deployment. Exactly one of online, batch or r_shiny must be set. Changing it replaces the deployment.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(requiresReplaceIfModeChanged, "Replaces the deployment if the deployment type changes.", "Replaces the deployment if the deployment type changes."),
+				},
 			},
 			"url": schema.StringAttribute{
-				Computed: true,
+				Description: "First serving URL of an online or R Shiny deployment.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(nil, servingPaths...),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of deployment, one of online, batch or r_shiny.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"status": schema.StringAttribute{
+				Description: "State of deployment, e.g. initializing, ready or failed.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					// Updates wait until the deployment is ready again.
+					useStateForUnknownUnlessChanged([]string{watsonmachinelearningv4.DeploymentEntityStatus_State_Ready}),
+				},
+			},
+			"status_message": schema.StringAttribute{
+				Description: "Status or failure message of deployment.",
+				Computed:    true,
+			},
+			"serving_urls": schema.ListAttribute{
+				Description: "Serving URLs of an online or R Shiny deployment, empty for batch deployments.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					useStateForUnknownUnlessChanged(nil, servingPaths...),
+				},
+			},
+			"validation": schema.SingleNestedAttribute{
+				Description: "Scores a sample payload once an online deployment is ready after create or update, and fails the apply if scoring fails, returns unexpected fields or is too slow.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"payload_file": schema.StringAttribute{
+						Description: "Path of a file with the fields and values to score. Either JSON, e.g. {\"fields\": [\"age\"], \"values\": [[42]]}, or CSV with the fields in the first row if the file name ends with .csv.",
+						Required:    true,
+					},
+					"expected_fields": schema.ListAttribute{
+						Description: "Fields every prediction must contain, e.g. prediction and probability.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"max_latency_ms": schema.Int64Attribute{
+						Description: "Maximum duration of the scoring request in milliseconds.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"rollback": schema.BoolAttribute{
+						Description: "Roll back if validation fails: a created deployment is deleted, an updated deployment is set back to its previous asset. Defaults to false, in which case a created deployment is tainted.",
+						Optional:    true,
+					},
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of deployment.",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Tags of deployment, used when searching for deployments.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"custom": schema.MapAttribute{
+				Description: "User defined properties of deployment.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"hardware_spec": schema.SingleNestedAttribute{
+				Description: "Hardware specification of deployment.",
+				Optional:    true,
+				Attributes:  deploymentHardwareSpecAttributes(),
+			},
+			"hybrid_pipeline_hardware_specs": schema.ListNestedAttribute{
+				Description: "Hardware specifications of the nodes of a hybrid pipeline.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_runtime_id": schema.StringAttribute{
+							Description: "ID of the node runtime, e.g. auto_ai.kb.",
+							Required:    true,
+						},
+						"hardware_spec": schema.SingleNestedAttribute{
+							Description: "Hardware specification of the node.",
+							Required:    true,
+							Attributes:  deploymentHardwareSpecAttributes(),
+						},
+					},
+				},
+			},
+			"copies": schema.Int64Attribute{
+				Description: "Number of copies of an online deployment, sent as number of nodes of the hardware specification.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.ConflictsWith(path.MatchRoot("hardware_spec").AtName("num_nodes")),
+				},
+			},
+			"r_shiny": schema.SingleNestedAttribute{
+				Description: "Deploys an R Shiny application. Exactly one of online, batch or r_shiny must be set. Adding or removing it replaces the deployment.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"authentication": schema.StringAttribute{
+						Description: "Who can access the application, one of " + strings.Join(rShinyAuthentications, ", ") + ".",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(rShinyAuthentications...),
+						},
+					},
+					"parameters": schema.SingleNestedAttribute{
+						Description: "Parameters of the application.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"serving_name": schema.StringAttribute{
+								Description: "Unique name used in the URL of the application.",
+								Optional:    true,
+							},
+							"code_package_path": schema.StringAttribute{
+								Description: "Path to the application files when deploying a code package asset.",
+								Optional:    true,
+							},
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+					}, "Replaces the deployment if r_shiny is added or removed.", "Replaces the deployment if `r_shiny` is added or removed."),
+				},
+			},
+			"app_url": schema.StringAttribute{
+				Description: "URL of the R Shiny application.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownUnlessChanged(nil, servingPaths...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *deploymentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		deploymentTypeValidator{},
+	}
+}
+
 func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan deploymentResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -102,6 +339,12 @@ func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_DEPLOYMENT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wmlClient, err := r.client.WMLClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
@@ -122,51 +365,46 @@ func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		Name:    core.StringPtr(plan.Name.ValueString()),
 		SpaceID: core.StringPtr(plan.SpaceID.ValueString()),
 		Asset: &watsonmachinelearningv4.Rel{
-			ID: core.StringPtr(plan.Asset.ValueString()),
+			ID:  core.StringPtr(plan.Asset.ValueString()),
+			Rev: utils.If(plan.AssetRev.ValueString() != "", core.StringPtr(plan.AssetRev.ValueString()), nil),
 		},
 		Online: utils.If(plan.Online.ValueBool(), &watsonmachinelearningv4.DeploymentEntityRequestOnline{
 			Parameters: servingUrlInterface,
 		}, nil),
-		Batch: utils.If(plan.Batch.ValueBool(), &watsonmachinelearningv4.DeploymentEntityRequestBatch{}, nil),
+		Batch:                       utils.If(plan.Batch.ValueBool(), &watsonmachinelearningv4.DeploymentEntityRequestBatch{}, nil),
+		Description:                 utils.If(plan.Description.ValueString() != "", core.StringPtr(plan.Description.ValueString()), nil),
+		Tags:                        utils.If(len(plan.Tags) > 0, utils.ConvertString(plan.Tags), nil),
+		Custom:                      deploymentCustom(plan.Custom),
+		HardwareSpec:                deploymentHardwareSpec(plan.HardwareSpec, plan.Copies),
+		HybridPipelineHardwareSpecs: hybridPipelineHardwareSpecs(plan.HybridPipelineHardwareSpecs),
+		RShiny:                      rShiny(plan.RShiny),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deploying Model", "Could not deploy model, unexpected error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Deploy Model", err.Error())
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Deploying Model", "Could not deploy model", response, err) {
 		return
 	}
 
-	var servingUrl string
-
-	for i := 1; i < NUM_TRIES_DEPLOYMENT; i++ {
-		deployment, response, err := wmlClient.DeploymentsGet(&watsonmachinelearningv4.DeploymentsGetOptions{
-			DeploymentID: deployment.Metadata.ID,
-			SpaceID:      core.StringPtr(plan.SpaceID.ValueString()),
-		})
+	// The deployment is saved even if it does not become ready, so that it
+	// is tainted rather than left behind.
+	result, err := waitForDeployment(ctx, wmlClient, *deployment.Metadata.ID, plan.SpaceID.ValueString(), createTimeout)
+	if utils.CheckWait(&resp.Diagnostics, "Error Waiting for Deployment", "Deployment ID "+*deployment.Metadata.ID+" is not ready", err) && plan.Validation != nil {
+		err = validateDeployment(ctx, wmlClient, result, plan.Validation)
 		if err != nil {
-			resp.Diagnostics.AddError("Error Getting Deployment", "Could not read Deployment ID "+*deployment.Metadata.ID+": "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Get Deployment", err.Error())
-			return
-		}
-		if len(deployment.Entity.Status.ServingUrls) > 0 {
-			servingUrl = deployment.Entity.Status.ServingUrls[0]
-			break
+			resp.Diagnostics.AddError("Deployment Validation Failed", "Deployment ID "+*deployment.Metadata.ID+" failed validation, "+err.Error())
+			if plan.Validation.Rollback.ValueBool() {
+				response, err := wmlClient.DeploymentsDelete(&watsonmachinelearningv4.DeploymentsDeleteOptions{
+					DeploymentID: deployment.Metadata.ID,
+					SpaceID:      core.StringPtr(plan.SpaceID.ValueString()),
+				})
+				if utils.CheckDeleteResponse(&resp.Diagnostics, "Error Rolling Back Deployment", "Could not delete deployment ID "+*deployment.Metadata.ID, response, err) {
+					return
+				}
+			}
 		}
-		time.Sleep(TIMEOUT_DEPLOYMENT)
-	}
-
-	if servingUrl == "" {
-		resp.Diagnostics.AddError("Error Getting Deployment URL", "Could not get deployment URL for "+*deployment.Metadata.ID+": "+err.Error())
 	}
 
 	plan.ID = types.StringValue(*deployment.Metadata.ID)
-	plan.URL = types.StringValue(deployment.Entity.Status.ServingUrls[0])
+	setDeploymentStatus(&plan, result)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -193,17 +431,15 @@ func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 		DeploymentID: core.StringPtr(state.ID.ValueString()),
 		SpaceID:      core.StringPtr(state.SpaceID.ValueString()),
 	})
-	if utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		state.ID = types.StringValue(*deployment.Metadata.ID)
-		state.URL = types.StringValue(deployment.Entity.Status.ServingUrls[0])
-		diags = resp.State.Set(ctx, &state)
-	} else if response.StatusCode == 404 {
-		diags = resp.State.Set(ctx, &deploymentResourceModel{})
-	} else {
-		resp.Diagnostics.AddError("Error Getting Deployment", "Could not read Deployment ID "+state.ID.ValueString()+". Error: "+err.Error())
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Deployment", "Could not read Deployment ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 
+	state.ID = types.StringValue(*deployment.Metadata.ID)
+	setDeploymentState(&state, deployment)
+
+	diags = resp.State.Set(ctx, &state)
+
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -211,7 +447,7 @@ func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 }
 
 func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var updateableFields = []string{"Name", "Asset", "ServingUrl"}
+	var updateableFields = []string{"Name", "Asset", "AssetRev", "ServingUrl", "Description"}
 	var diags diag.Diagnostics
 
 	var state deploymentResourceModel
@@ -228,15 +464,28 @@ func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_DEPLOYMENT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var jsonPatches []watsonmachinelearningv4.JSONPatchOperation
 	for _, field := range updateableFields {
 		if utils.GetAttr(&plan, field).Interface().(types.String).ValueString() != utils.GetAttr(&state, field).Interface().(types.String).ValueString() {
 			switch field {
-			case "Asset":
+			case "Asset", "AssetRev":
+				if field == "AssetRev" && plan.Asset.ValueString() != state.Asset.ValueString() {
+					// Already patched together with the asset.
+					continue
+				}
 				jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
-					Op:    core.StringPtr("replace"),
-					Path:  core.StringPtr(fmt.Sprintf(`/%s`, strings.ToLower(field))),
-					Value: &watsonmachinelearningv4.Rel{ID: core.StringPtr(plan.Asset.ValueString())},
+					Op:   core.StringPtr("replace"),
+					Path: core.StringPtr("/asset"),
+					Value: &watsonmachinelearningv4.Rel{
+						ID:  core.StringPtr(plan.Asset.ValueString()),
+						Rev: utils.If(plan.AssetRev.ValueString() != "", core.StringPtr(plan.AssetRev.ValueString()), nil),
+					},
 				})
 			case "ServingUrl":
 				if plan.ServingUrl.ValueString() != "" {
@@ -263,28 +512,79 @@ func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequ
 		}
 	}
 
+	if !reflect.DeepEqual(plan.Tags, state.Tags) {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/tags"),
+			Value: utils.ConvertString(plan.Tags),
+		})
+	}
+	if !reflect.DeepEqual(plan.Custom, state.Custom) {
+		custom := deploymentCustom(plan.Custom)
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr(utils.If(custom != nil, "replace", "remove")),
+			Path:  core.StringPtr("/custom"),
+			Value: custom,
+		})
+	}
+	if !reflect.DeepEqual(plan.HardwareSpec, state.HardwareSpec) || !plan.Copies.Equal(state.Copies) {
+		hardwareSpec := deploymentHardwareSpec(plan.HardwareSpec, plan.Copies)
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr(utils.If(hardwareSpec != nil, "replace", "remove")),
+			Path:  core.StringPtr("/hardware_spec"),
+			Value: hardwareSpec,
+		})
+	}
+	if plan.RShiny != nil && !reflect.DeepEqual(plan.RShiny, state.RShiny) {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/r_shiny"),
+			Value: rShiny(plan.RShiny),
+		})
+	}
+	if !reflect.DeepEqual(plan.HybridPipelineHardwareSpecs, state.HybridPipelineHardwareSpecs) {
+		hybridSpecs := hybridPipelineHardwareSpecs(plan.HybridPipelineHardwareSpecs)
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr(utils.If(hybridSpecs != nil, "replace", "remove")),
+			Path:  core.StringPtr("/hybrid_pipeline_hardware_specs"),
+			Value: hybridSpecs,
+		})
+	}
+
 	wmlClient, err := r.client.WMLClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
 		return
 	}
 
-	deployment, response, err := wmlClient.DeploymentsUpdate(&watsonmachinelearningv4.DeploymentsUpdateOptions{
-		DeploymentID: core.StringPtr(plan.ID.ValueString()),
-		SpaceID:      core.StringPtr(plan.SpaceID.ValueString()),
-		JSONPatch:    jsonPatches,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Updating Deployment", "Could not update deployment ID "+plan.ID.ValueString()+": "+err.Error())
-		return
+	// Changes of validation alone are not sent, but validate the deployment
+	// again.
+	if len(jsonPatches) > 0 {
+		_, response, err := wmlClient.DeploymentsUpdate(&watsonmachinelearningv4.DeploymentsUpdateOptions{
+			DeploymentID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:      core.StringPtr(plan.SpaceID.ValueString()),
+			JSONPatch:    jsonPatches,
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Deployment", "Could not update deployment ID "+state.ID.ValueString(), response, err) {
+			return
+		}
 	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Update Deployment", err.Error())
-		return
+
+	result, err := waitForDeployment(ctx, wmlClient, state.ID.ValueString(), plan.SpaceID.ValueString(), updateTimeout)
+	if utils.CheckWait(&resp.Diagnostics, "Error Waiting for Deployment", "Deployment ID "+state.ID.ValueString()+" is not ready", err) && plan.Validation != nil {
+		err = validateDeployment(ctx, wmlClient, result, plan.Validation)
+		if err != nil {
+			resp.Diagnostics.AddError("Deployment Validation Failed", "Deployment ID "+state.ID.ValueString()+" failed validation, "+err.Error())
+			if plan.Validation.Rollback.ValueBool() && (!plan.Asset.Equal(state.Asset) || !plan.AssetRev.Equal(state.AssetRev)) {
+				result = r.rollbackAsset(ctx, wmlClient, &state, updateTimeout, &resp.Diagnostics)
+				plan.Asset = state.Asset
+				plan.AssetRev = state.AssetRev
+			}
+		}
 	}
 
-	plan.ID = types.StringValue(*deployment.Metadata.ID)
-	plan.URL = types.StringValue(deployment.Entity.Status.ServingUrls[0])
+	plan.ID = state.ID
+	setDeploymentStatus(&plan, result)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -301,6 +601,12 @@ func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_DEPLOYMENT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wmlClient, err := r.client.WMLClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
@@ -312,16 +618,446 @@ func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequ
 		SpaceID:      core.StringPtr(state.SpaceID.ValueString()),
 	})
 
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Deployment", "Could not delete deployment ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	err = utils.WaitForDeletion(ctx, deleteTimeout, func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := wmlClient.DeploymentsGetWithContext(ctx, &watsonmachinelearningv4.DeploymentsGetOptions{
+			DeploymentID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:      core.StringPtr(state.SpaceID.ValueString()),
+		})
+		return response, err
+	})
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Deployment", "Deployment ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+func (r *deploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// rollbackAsset sets the deployment back to the asset of state after a failed
+// validation. It returns the deployment read after the rollback, or nil.
+func (r *deploymentResource) rollbackAsset(ctx context.Context, wmlClient *watsonmachinelearningv4.WatsonMachineLearningV4, state *deploymentResourceModel, timeout time.Duration, diags *diag.Diagnostics) *watsonmachinelearningv4.DeploymentResource {
+	_, response, err := wmlClient.DeploymentsUpdate(&watsonmachinelearningv4.DeploymentsUpdateOptions{
+		DeploymentID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:      core.StringPtr(state.SpaceID.ValueString()),
+		JSONPatch: []watsonmachinelearningv4.JSONPatchOperation{
+			{
+				Op:   core.StringPtr("replace"),
+				Path: core.StringPtr("/asset"),
+				Value: &watsonmachinelearningv4.Rel{
+					ID:  core.StringPtr(state.Asset.ValueString()),
+					Rev: utils.If(state.AssetRev.ValueString() != "", core.StringPtr(state.AssetRev.ValueString()), nil),
+				},
+			},
+		},
+	})
+	if !utils.CheckResponse(diags, "Error Rolling Back Deployment", "Could not set deployment ID "+state.ID.ValueString()+" back to asset "+state.Asset.ValueString(), response, err) {
+		return nil
+	}
+	result, err := waitForDeployment(ctx, wmlClient, state.ID.ValueString(), state.SpaceID.ValueString(), timeout)
+	utils.CheckWait(diags, "Error Rolling Back Deployment", "Deployment ID "+state.ID.ValueString()+" is not ready after rollback", err)
+	return result
+}
+
+// validateDeployment scores the payload file of validation with deployment and
+// checks the fields of the predictions and the latency.
+func validateDeployment(ctx context.Context, wmlClient *watsonmachinelearningv4.WatsonMachineLearningV4, deployment *watsonmachinelearningv4.DeploymentResource, validation *deploymentValidationModel) error {
+	if deployment.Entity.Status == nil || len(deployment.Entity.Status.ServingUrls) == 0 {
+		return fmt.Errorf("deployment has no serving URL, validation requires an online deployment")
+	}
+
+	payload, err := readScoringPayload(validation.PayloadFile.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Deployment", "Could not delete deployment ID "+state.ID.ValueString()+": "+err.Error())
+		return fmt.Errorf("unable to read payload file: %w", err)
+	}
+
+	start := time.Now()
+	predictions, response, err := wmlClient.DeploymentsComputePredictionsWithContext(ctx, &watsonmachinelearningv4.DeploymentsComputePredictionsOptions{
+		DeploymentID: deployment.Metadata.ID,
+		InputData:    []watsonmachinelearningv4.InputDataArray{payload},
+	})
+	latency := time.Since(start).Milliseconds()
+	if err := utils.ResponseError(response, err); err != nil {
+		return fmt.Errorf("scoring failed: %w", err)
+	}
+	tflog.Debug(ctx, "Validated deployment", map[string]interface{}{"deployment_id": *deployment.Metadata.ID, "latency_ms": latency})
+
+	if len(predictions.Predictions) == 0 {
+		return fmt.Errorf("scoring returned no predictions")
+	}
+	for _, prediction := range predictions.Predictions {
+		var missing []string
+		for _, field := range validation.ExpectedFields {
+			if !utils.Contains(prediction.Fields, field.ValueString()) {
+				missing = append(missing, field.ValueString())
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("prediction is missing fields %s, got %s", strings.Join(missing, ", "), strings.Join(prediction.Fields, ", "))
+		}
+	}
+
+	if !validation.MaxLatencyMs.IsNull() && latency > validation.MaxLatencyMs.ValueInt64() {
+		return fmt.Errorf("scoring took %d ms, more than max_latency_ms %d ms", latency, validation.MaxLatencyMs.ValueInt64())
+	}
+	return nil
+}
+
+// waitForDeployment waits until the deployment is ready. It returns the last
+// deployment read, which is nil if none could be read.
+func waitForDeployment(ctx context.Context, wmlClient *watsonmachinelearningv4.WatsonMachineLearningV4, deploymentID string, spaceID string, timeout time.Duration) (*watsonmachinelearningv4.DeploymentResource, error) {
+	waiter := utils.StateWaiter[*watsonmachinelearningv4.DeploymentResource]{
+		Pending: []string{watsonmachinelearningv4.DeploymentEntityStatus_State_Initializing, watsonmachinelearningv4.DeploymentEntityStatus_State_Updating},
+		Target:  []string{watsonmachinelearningv4.DeploymentEntityStatus_State_Ready},
+		Failed:  []string{watsonmachinelearningv4.DeploymentEntityStatus_State_Failed},
+		Refresh: func(ctx context.Context) (*watsonmachinelearningv4.DeploymentResource, string, error) {
+			deployment, response, err := wmlClient.DeploymentsGetWithContext(ctx, &watsonmachinelearningv4.DeploymentsGetOptions{
+				DeploymentID: core.StringPtr(deploymentID),
+				SpaceID:      core.StringPtr(spaceID),
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			if deployment.Entity == nil || deployment.Entity.Status == nil || deployment.Entity.Status.State == nil {
+				return deployment, "", nil
+			}
+			return deployment, *deployment.Entity.Status.State, nil
+		},
+		FailureMessage: deploymentFailure,
+		Timeout:        timeout,
+	}
+	return waiter.Wait(ctx)
+}
+
+// deploymentFailure returns the failure message of a failed deployment.
+func deploymentFailure(deployment *watsonmachinelearningv4.DeploymentResource) string {
+	status := deployment.Entity.Status
+	if status.Failure != nil && len(status.Failure.Errors) > 0 && status.Failure.Errors[0].Message != nil {
+		return *status.Failure.Errors[0].Message
+	}
+	if status.Message != nil && status.Message.Text != nil {
+		return *status.Message.Text
+	}
+	return ""
+}
+
+// deploymentType returns the type of deployment, or an empty string if it is
+// not known.
+func deploymentType(entity *watsonmachinelearningv4.DeploymentEntity) string {
+	switch {
+	case entity == nil:
+		return ""
+	case entity.Online != nil:
+		return DEPLOYMENT_TYPE_ONLINE
+	case entity.Batch != nil:
+		return DEPLOYMENT_TYPE_BATCH
+	case entity.RShiny != nil:
+		return DEPLOYMENT_TYPE_R_SHINY
+	}
+	return ""
+}
+
+// setDeploymentStatus sets the computed status attributes of model from
+// deployment, which may be nil if it could not be read. Only online and R
+// Shiny deployments have serving URLs.
+func setDeploymentStatus(model *deploymentResourceModel, deployment *watsonmachinelearningv4.DeploymentResource) {
+	model.Type = types.StringNull()
+	model.Status = types.StringNull()
+	model.StatusMessage = types.StringNull()
+	model.ServingURLs = nil
+	model.URL = types.StringNull()
+	model.AppURL = types.StringNull()
+	if deployment == nil || deployment.Entity == nil {
 		return
 	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Delete Model", err.Error())
+
+	deploymentType := deploymentType(deployment.Entity)
+	model.Type = utils.StringValueOrNull(&deploymentType)
+
+	status := deployment.Entity.Status
+	if status == nil {
 		return
 	}
+	model.Status = utils.StringValueOrNull(status.State)
+	message := deploymentFailure(deployment)
+	model.StatusMessage = utils.StringValueOrNull(&message)
+
+	if deploymentType == DEPLOYMENT_TYPE_BATCH {
+		model.ServingURLs = []types.String{}
+		return
+	}
+	model.ServingURLs = utils.StringList(status.ServingUrls)
+	if len(status.ServingUrls) > 0 {
+		model.URL = types.StringValue(status.ServingUrls[0])
+		if deploymentType == DEPLOYMENT_TYPE_R_SHINY {
+			model.AppURL = model.URL
+		}
+	}
 }
 
-func (r *deploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// requiresReplaceIfModeChanged replaces a deployment if online or batch
+// changes, since the type of a deployment cannot be updated. Null and false
+// are equal.
+func requiresReplaceIfModeChanged(_ context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = req.StateValue.ValueBool() != req.PlanValue.ValueBool()
+}
+
+// deploymentTypeValidator checks that exactly one of online, batch or r_shiny
+// is set, where online and batch count if true, and that validation is only
+// set for online deployments.
+type deploymentTypeValidator struct{}
+
+func (v deploymentTypeValidator) Description(_ context.Context) string {
+	return "Exactly one of online = true, batch = true or r_shiny must be set."
+}
+
+func (v deploymentTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return "Exactly one of `online = true`, `batch = true` or `r_shiny` must be set."
+}
+
+func (v deploymentTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var online, batch types.Bool
+	var rShiny types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("online"), &online)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("batch"), &batch)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("r_shiny"), &rShiny)...)
+	if resp.Diagnostics.HasError() || online.IsUnknown() || batch.IsUnknown() || rShiny.IsUnknown() {
+		return
+	}
+
+	var modes []string
+	if online.ValueBool() {
+		modes = append(modes, DEPLOYMENT_TYPE_ONLINE)
+	}
+	if batch.ValueBool() {
+		modes = append(modes, DEPLOYMENT_TYPE_BATCH)
+	}
+	if !rShiny.IsNull() {
+		modes = append(modes, DEPLOYMENT_TYPE_R_SHINY)
+	}
+	switch len(modes) {
+	case 0:
+		resp.Diagnostics.AddError("Missing Deployment Type", "One of online = true, batch = true or r_shiny must be set.")
+	case 1:
+	default:
+		resp.Diagnostics.AddError("Conflicting Deployment Types", "Only one of online = true, batch = true or r_shiny can be set, got "+strings.Join(modes, ", ")+".")
+	}
+
+	var validation types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("validation"), &validation)...)
+	if !validation.IsNull() && !validation.IsUnknown() && !online.ValueBool() {
+		resp.Diagnostics.AddAttributeError(path.Root("validation"), "Invalid Validation", "Validation scores a payload and is only supported for online deployments.")
+	}
+}
+
+// setDeploymentState refreshes the configurable attributes of state from
+// deployment.
+func setDeploymentState(state *deploymentResourceModel, deployment *watsonmachinelearningv4.DeploymentResource) {
+	entity := deployment.Entity
+	if entity == nil {
+		return
+	}
+	if entity.Name != nil {
+		state.Name = types.StringValue(*entity.Name)
+	}
+	if entity.Asset != nil {
+		state.Asset = utils.RefreshOptionalString(state.Asset, entity.Asset.ID)
+		state.AssetRev = utils.RefreshOptionalString(state.AssetRev, entity.Asset.Rev)
+	}
+	if !state.Online.IsNull() || entity.Online != nil {
+		state.Online = types.BoolValue(entity.Online != nil)
+	}
+	if !state.Batch.IsNull() || entity.Batch != nil {
+		state.Batch = types.BoolValue(entity.Batch != nil)
+	}
+
+	var servingName *string
+	if entity.Online != nil {
+		if parameters, ok := entity.Online.Parameters.(map[string]interface{}); ok {
+			if name, ok := parameters["serving_name"].(string); ok {
+				servingName = &name
+			}
+		}
+	}
+	state.ServingUrl = utils.RefreshOptionalString(state.ServingUrl, servingName)
+
+	state.Description = utils.RefreshOptionalString(state.Description, entity.Description)
+	if state.Tags != nil || len(entity.Tags) > 0 {
+		state.Tags = utils.StringList(entity.Tags)
+	}
+	if state.Custom != nil || len(entity.Custom) > 0 {
+		state.Custom = make(map[string]types.String, len(entity.Custom))
+		for k, v := range entity.Custom {
+			if value, ok := v.(string); ok {
+				state.Custom[k] = types.StringValue(value)
+			} else {
+				value, _ := json.Marshal(v)
+				state.Custom[k] = types.StringValue(string(value))
+			}
+		}
+	}
+
+	if entity.HardwareSpec != nil {
+		if state.HardwareSpec != nil {
+			state.HardwareSpec = refreshHardwareSpec(state.HardwareSpec, entity.HardwareSpec)
+		}
+		if !state.Copies.IsNull() && entity.HardwareSpec.NumNodes != nil {
+			state.Copies = types.Int64Value(*entity.HardwareSpec.NumNodes)
+		}
+	}
+	if state.HybridPipelineHardwareSpecs != nil || len(entity.HybridPipelineHardwareSpecs) > 0 {
+		hybridSpecs := make([]hybridPipelineHardwareSpecModel, len(entity.HybridPipelineHardwareSpecs))
+		for i, v := range entity.HybridPipelineHardwareSpecs {
+			var prior *deploymentHardwareSpecModel
+			if i < len(state.HybridPipelineHardwareSpecs) {
+				prior = &state.HybridPipelineHardwareSpecs[i].HardwareSpec
+			}
+			hybridSpecs[i] = hybridPipelineHardwareSpecModel{
+				NodeRuntimeID: utils.StringValueOrNull(v.NodeRuntimeID),
+			}
+			if hardwareSpec := refreshHardwareSpec(prior, v.HardwareSpec); hardwareSpec != nil {
+				hybridSpecs[i].HardwareSpec = *hardwareSpec
+			}
+		}
+		state.HybridPipelineHardwareSpecs = hybridSpecs
+	}
+
+	if entity.RShiny != nil {
+		rShinyState := &rShinyModel{
+			Authentication: utils.StringValueOrNull(entity.RShiny.Authentication),
+		}
+		if state.RShiny != nil && state.RShiny.Parameters != nil && entity.RShiny.Parameters != nil {
+			var codePackagePath *string
+			if entity.RShiny.Parameters.CodePackage != nil {
+				codePackagePath = entity.RShiny.Parameters.CodePackage.Path
+			}
+			rShinyState.Parameters = &rShinyParametersModel{
+				ServingName:     utils.RefreshOptionalString(state.RShiny.Parameters.ServingName, entity.RShiny.Parameters.ServingName),
+				CodePackagePath: utils.RefreshOptionalString(state.RShiny.Parameters.CodePackagePath, codePackagePath),
+			}
+		}
+		state.RShiny = rShinyState
+	} else {
+		state.RShiny = nil
+	}
+	setDeploymentStatus(state, deployment)
+}
+
+func deploymentHardwareSpecAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "ID of hardware spec.",
+			Optional:    true,
+			Validators: []validator.String{
+				stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("name")),
+			},
+		},
+		"name": schema.StringAttribute{
+			Description: "Name of hardware spec, e.g. S, M or L.",
+			Optional:    true,
+		},
+		"num_nodes": schema.Int64Attribute{
+			Description: "Number of nodes.",
+			Optional:    true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+	}
+}
+
+func hardwareSpecRel(model deploymentHardwareSpecModel) *watsonmachinelearningv4.HardwareSpecRel {
+	return &watsonmachinelearningv4.HardwareSpecRel{
+		ID:       utils.If(model.ID.ValueString() != "", core.StringPtr(model.ID.ValueString()), nil),
+		Name:     utils.If(model.Name.ValueString() != "", core.StringPtr(model.Name.ValueString()), nil),
+		NumNodes: utils.If(!model.NumNodes.IsNull(), core.Int64Ptr(model.NumNodes.ValueInt64()), nil),
+	}
+}
+
+// deploymentHardwareSpec returns the hardware spec of a deployment, with the
+// number of copies as number of nodes.
+func deploymentHardwareSpec(model *deploymentHardwareSpecModel, copies types.Int64) *watsonmachinelearningv4.HardwareSpecRel {
+	if model == nil && copies.IsNull() {
+		return nil
+	}
+	hardwareSpec := &watsonmachinelearningv4.HardwareSpecRel{}
+	if model != nil {
+		hardwareSpec = hardwareSpecRel(*model)
+	}
+	if !copies.IsNull() {
+		hardwareSpec.NumNodes = core.Int64Ptr(copies.ValueInt64())
+	}
+	return hardwareSpec
+}
+
+func hybridPipelineHardwareSpecs(models []hybridPipelineHardwareSpecModel) []watsonmachinelearningv4.JobEntityResultHybridPipelineHardwareSpecsItem {
+	if len(models) == 0 {
+		return nil
+	}
+	hybridSpecs := make([]watsonmachinelearningv4.JobEntityResultHybridPipelineHardwareSpecsItem, len(models))
+	for i, v := range models {
+		hybridSpecs[i] = watsonmachinelearningv4.JobEntityResultHybridPipelineHardwareSpecsItem{
+			NodeRuntimeID: core.StringPtr(v.NodeRuntimeID.ValueString()),
+			HardwareSpec:  hardwareSpecRel(v.HardwareSpec),
+		}
+	}
+	return hybridSpecs
+}
+
+func deploymentCustom(custom map[string]types.String) map[string]interface{} {
+	if len(custom) == 0 {
+		return nil
+	}
+	result := make(map[string]interface{}, len(custom))
+	for k, v := range custom {
+		result[k] = v.ValueString()
+	}
+	return result
+}
+
+// refreshHardwareSpec returns the hardware spec read from the API. Attributes
+// not set in prior stay null, since the API adds e.g. the ID to a hardware
+// spec given by name. Without prior the ID and number of nodes are read.
+func refreshHardwareSpec(prior *deploymentHardwareSpecModel, hardwareSpec *watsonmachinelearningv4.HardwareSpecRel) *deploymentHardwareSpecModel {
+	if hardwareSpec == nil {
+		return prior
+	}
+	if prior == nil {
+		prior = &deploymentHardwareSpecModel{
+			ID:       types.StringValue(""),
+			Name:     types.StringNull(),
+			NumNodes: types.Int64Value(0),
+		}
+	}
+	result := &deploymentHardwareSpecModel{
+		ID:       utils.RefreshOptionalString(prior.ID, hardwareSpec.ID),
+		Name:     utils.RefreshOptionalString(prior.Name, hardwareSpec.Name),
+		NumNodes: types.Int64Null(),
+	}
+	if !prior.NumNodes.IsNull() && hardwareSpec.NumNodes != nil {
+		result.NumNodes = types.Int64Value(*hardwareSpec.NumNodes)
+	}
+	return result
+}
+
+func rShiny(model *rShinyModel) *watsonmachinelearningv4.DeploymentEntityRequestRShiny {
+	if model == nil {
+		return nil
+	}
+	result := &watsonmachinelearningv4.DeploymentEntityRequestRShiny{
+		Authentication: core.StringPtr(model.Authentication.ValueString()),
+	}
+	if model.Parameters != nil {
+		result.Parameters = &watsonmachinelearningv4.DeploymentEntityRequestRShinyParameters{
+			ServingName: utils.If(model.Parameters.ServingName.ValueString() != "", core.StringPtr(model.Parameters.ServingName.ValueString()), nil),
+		}
+		if model.Parameters.CodePackagePath.ValueString() != "" {
+			result.Parameters.CodePackage = &watsonmachinelearningv4.DeploymentEntityRequestRShinyParametersCodePackage{
+				Path: core.StringPtr(model.Parameters.CodePackagePath.ValueString()),
+			}
+		}
+	}
+	return result
 }
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DEFAULT_TIMEOUT_DEPLOYMENT_JOB = 20 * time.Minute
+
+var (
+	_ resource.Resource                = &deploymentJobResource{}
+	_ resource.ResourceWithConfigure   = &deploymentJobResource{}
+	_ resource.ResourceWithImportState = &deploymentJobResource{}
+)
+
+type deploymentJobResource struct {
+	client *client.Client
+}
+
+type deploymentJobResourceModel struct {
+	ID                   types.String            `tfsdk:"id"`
+	SpaceID              types.String            `tfsdk:"space_id"`
+	Name                 types.String            `tfsdk:"name"`
+	DeploymentID         types.String            `tfsdk:"deployment_id"`
+	HardwareSpec         *jobHardwareSpecModel   `tfsdk:"hardware_spec"`
+	InputDataReferences  []jobDataReferenceModel `tfsdk:"input_data_references"`
+	OutputDataReference  *jobDataReferenceModel  `tfsdk:"output_data_reference"`
+	EnvironmentVariables map[string]types.String `tfsdk:"environment_variables"`
+	WaitForCompletion    types.Bool              `tfsdk:"wait_for_completion"`
+	Status               types.String            `tfsdk:"status"`
+	StatusMessage        types.String            `tfsdk:"status_message"`
+	Errors               []jobErrorModel         `tfsdk:"errors"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type jobErrorModel struct {
+	Code    types.String `tfsdk:"code"`
+	Message types.String `tfsdk:"message"`
+}
+
+func NewDeploymentJobResource() resource.Resource {
+	return &deploymentJobResource{}
+}
+
+func (r *deploymentJobResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *deploymentJobResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_job"
+}
+
+func (r *deploymentJobResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	hardwareSpec := jobHardwareSpecAttribute()
+	hardwareSpec.PlanModifiers = []planmodifier.Object{objectplanmodifier.RequiresReplace()}
+	inputDataReferences := jobInputDataReferencesAttribute()
+	inputDataReferences.PlanModifiers = []planmodifier.List{listplanmodifier.RequiresReplace()}
+	outputDataReference := jobOutputDataReferenceAttribute()
+	outputDataReference.PlanModifiers = []planmodifier.Object{objectplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "Runs a job of a batch deployment on IBM Cloud Pak for Data. Any change runs a new job.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for job.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID of job.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of job.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deployment_id": schema.StringAttribute{
+				Description: "ID of the batch deployment to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware_spec":         hardwareSpec,
+			"input_data_references": inputDataReferences,
+			"output_data_reference": outputDataReference,
+			"environment_variables": schema.MapAttribute{
+				Description: "Environment variables of the scoring job.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "Wait until the job is completed, failed or canceled. Fails if the job does not complete.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of job.",
+				Computed:    true,
+			},
+			"status_message": schema.StringAttribute{
+				Description: "Status message of job.",
+				Computed:    true,
+			},
+			"errors": schema.ListNestedAttribute{
+				Description: "Errors reported by the job.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Error code.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Error message.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *deploymentJobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deploymentJobResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_DEPLOYMENT_JOB)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	job, response, err := wmlClient.DeploymentJobsCreate(&watsonmachinelearningv4.DeploymentJobsCreateOptions{
+		SpaceID: core.StringPtr(plan.SpaceID.ValueString()),
+		Name:    utils.If(plan.Name.ValueString() != "", core.StringPtr(plan.Name.ValueString()), nil),
+		Deployment: &watsonmachinelearningv4.SimpleRel{
+			ID: core.StringPtr(plan.DeploymentID.ValueString()),
+		},
+		HardwareSpec: jobHardwareSpec(plan.HardwareSpec),
+		Scoring:      jobScoringRequest(plan.InputDataReferences, plan.OutputDataReference, plan.EnvironmentVariables),
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Deployment Job", "Could not create deployment job", response, err) {
+		return
+	}
+
+	plan.ID = types.StringValue(*job.Metadata.ID)
+	setDeploymentJobState(&plan, job)
+	tflog.Info(ctx, "Created Deployment Job", map[string]interface{}{"job_id": plan.ID.ValueString(), "status": plan.Status.ValueString()})
+
+	if plan.WaitForCompletion.ValueBool() {
+		waiter := utils.StateWaiter[*watsonmachinelearningv4.JobsResource]{
+			Pending: []string{watsonmachinelearningv4.JobStatus_State_Queued, watsonmachinelearningv4.JobStatus_State_Running},
+			Target:  []string{watsonmachinelearningv4.JobStatus_State_Completed},
+			Failed:  []string{watsonmachinelearningv4.JobStatus_State_Failed, watsonmachinelearningv4.JobStatus_State_Canceled},
+			Refresh: func(ctx context.Context) (*watsonmachinelearningv4.JobsResource, string, error) {
+				job, response, err := wmlClient.DeploymentJobsGetWithContext(ctx, &watsonmachinelearningv4.DeploymentJobsGetOptions{
+					JobID:   core.StringPtr(plan.ID.ValueString()),
+					SpaceID: core.StringPtr(plan.SpaceID.ValueString()),
+				})
+				if err := utils.ResponseError(response, err); err != nil {
+					return nil, "", err
+				}
+				setDeploymentJobState(&plan, job)
+				tflog.Info(ctx, "Deployment Job Status", map[string]interface{}{"job_id": plan.ID.ValueString(), "status": plan.Status.ValueString()})
+				return job, plan.Status.ValueString(), nil
+			},
+			FailureMessage: func(*watsonmachinelearningv4.JobsResource) string {
+				return plan.StatusMessage.ValueString()
+			},
+			Timeout: createTimeout,
+		}
+		_, err = waiter.Wait(ctx)
+		if !utils.CheckWait(&resp.Diagnostics, "Error Running Deployment Job", "Deployment job ID "+plan.ID.ValueString()+" is not completed", err) {
+			// Keep the job in state so that its status and errors can be inspected.
+			diags = resp.State.Set(ctx, plan)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deploymentJobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	job, response, err := wmlClient.DeploymentJobsGet(&watsonmachinelearningv4.DeploymentJobsGetOptions{
+		JobID:   core.StringPtr(state.ID.ValueString()),
+		SpaceID: core.StringPtr(state.SpaceID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Deployment Job", "Could not read deployment job ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	if job.Metadata != nil {
+		state.Name = utils.RefreshOptionalString(state.Name, job.Metadata.Name)
+	}
+	if job.Entity != nil && job.Entity.Deployment != nil && job.Entity.Deployment.ID != nil {
+		state.DeploymentID = types.StringValue(*job.Entity.Deployment.ID)
+	}
+	setDeploymentJobState(&state, job)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state deploymentJobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan deploymentJobResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All other attributes require replacement, so only timeouts can change.
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deploymentJobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_DEPLOYMENT_JOB)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	response, err := wmlClient.DeploymentJobsDelete(&watsonmachinelearningv4.DeploymentJobsDeleteOptions{
+		JobID:      core.StringPtr(state.ID.ValueString()),
+		SpaceID:    core.StringPtr(state.SpaceID.ValueString()),
+		HardDelete: core.BoolPtr(true),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Deployment Job", "Could not delete deployment job ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	err = utils.WaitForDeletion(ctx, deleteTimeout, func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := wmlClient.DeploymentJobsGetWithContext(ctx, &watsonmachinelearningv4.DeploymentJobsGetOptions{
+			JobID:   core.StringPtr(state.ID.ValueString()),
+			SpaceID: core.StringPtr(state.SpaceID.ValueString()),
+		})
+		return response, err
+	})
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Deployment Job", "Deployment job ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+func (r *deploymentJobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.SplitN(req.ID, "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected import identifier with format <space_id>/<job_id>. Got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+}
+
+func setDeploymentJobState(state *deploymentJobResourceModel, job *watsonmachinelearningv4.JobsResource) {
+	state.Status = types.StringNull()
+	state.StatusMessage = types.StringNull()
+	state.Errors = nil
+	if job == nil || job.Entity == nil || job.Entity.Scoring == nil || job.Entity.Scoring.Status == nil {
+		return
+	}
+	status := job.Entity.Scoring.Status
+	state.Status = utils.StringValueOrNull(status.State)
+	if status.Message != nil {
+		state.StatusMessage = utils.StringValueOrNull(status.Message.Text)
+	}
+	if status.Failure != nil {
+		for _, v := range status.Failure.Errors {
+			state.Errors = append(state.Errors, jobErrorModel{
+				Code:    utils.StringValueOrNull(v.Code),
+				Message: utils.StringValueOrNull(v.Message),
+			})
+		}
+	}
+}
@@ -0,0 +1,645 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &deploymentJobDefinitionResource{}
+	_ resource.ResourceWithConfigure   = &deploymentJobDefinitionResource{}
+	_ resource.ResourceWithImportState = &deploymentJobDefinitionResource{}
+)
+
+type deploymentJobDefinitionResource struct {
+	client *client.Client
+}
+
+type deploymentJobDefinitionResourceModel struct {
+	ID                   types.String            `tfsdk:"id"`
+	SpaceID              types.String            `tfsdk:"space_id"`
+	Name                 types.String            `tfsdk:"name"`
+	Description          types.String            `tfsdk:"description"`
+	DeploymentID         types.String            `tfsdk:"deployment_id"`
+	HardwareSpec         *jobHardwareSpecModel   `tfsdk:"hardware_spec"`
+	InputDataReferences  []jobDataReferenceModel `tfsdk:"input_data_references"`
+	OutputDataReference  *jobDataReferenceModel  `tfsdk:"output_data_reference"`
+	EnvironmentVariables map[string]types.String `tfsdk:"environment_variables"`
+	Schedule             types.String            `tfsdk:"schedule"`
+	PlatformJobID        types.String            `tfsdk:"platform_job_id"`
+	Rev                  types.String            `tfsdk:"rev"`
+}
+
+type jobHardwareSpecModel struct {
+	Name     types.String `tfsdk:"name"`
+	NumNodes types.Int64  `tfsdk:"num_nodes"`
+}
+
+type jobDataReferenceModel struct {
+	Type         types.String            `tfsdk:"type"`
+	ConnectionID types.String            `tfsdk:"connection_id"`
+	Location     map[string]types.String `tfsdk:"location"`
+}
+
+func NewDeploymentJobDefinitionResource() resource.Resource {
+	return &deploymentJobDefinitionResource{}
+}
+
+func (r *deploymentJobDefinitionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *deploymentJobDefinitionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment_job_definition"
+}
+
+func (r *deploymentJobDefinitionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a job definition of a batch deployment on IBM Cloud Pak for Data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for job definition.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID of job definition.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of job definition.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of job definition.",
+				Optional:    true,
+			},
+			"deployment_id": schema.StringAttribute{
+				Description: "ID of the batch deployment to run.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware_spec":         jobHardwareSpecAttribute(),
+			"input_data_references": jobInputDataReferencesAttribute(),
+			"output_data_reference": jobOutputDataReferenceAttribute(),
+			"environment_variables": schema.MapAttribute{
+				Description: "Environment variables of the scoring job.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"schedule": schema.StringAttribute{
+				Description: "Cron expression to run the job definition on a schedule, e.g. `0 2 * * *`.",
+				Optional:    true,
+			},
+			"platform_job_id": schema.StringAttribute{
+				Description: "ID of the platform job running the job definition on schedule.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rev": schema.StringAttribute{
+				Description: "Latest revision of job definition. A new revision is created on every update.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *deploymentJobDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan deploymentJobDefinitionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	jobDefinition, response, err := wmlClient.DeploymentJobDefinitionsCreate(&watsonmachinelearningv4.DeploymentJobDefinitionsCreateOptions{
+		SpaceID:     core.StringPtr(plan.SpaceID.ValueString()),
+		Name:        core.StringPtr(plan.Name.ValueString()),
+		Description: utils.If(plan.Description.ValueString() != "", core.StringPtr(plan.Description.ValueString()), nil),
+		Deployment: &watsonmachinelearningv4.SimpleRel{
+			ID: core.StringPtr(plan.DeploymentID.ValueString()),
+		},
+		HardwareSpec: jobHardwareSpec(plan.HardwareSpec),
+		Scoring:      jobScoringRequest(plan.InputDataReferences, plan.OutputDataReference, plan.EnvironmentVariables),
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Job Definition", "Could not create job definition", response, err) {
+		return
+	}
+
+	plan.ID = types.StringValue(*jobDefinition.Metadata.ID)
+	plan.Rev = utils.StringValueOrNull(jobDefinition.Metadata.Rev)
+	plan.PlatformJobID = types.StringNull()
+
+	if plan.Schedule.ValueString() != "" {
+		platformJobID, err := r.createPlatformJob(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Scheduling Job Definition", "Could not schedule job definition ID "+plan.ID.ValueString()+": "+err.Error())
+			_, _ = wmlClient.DeploymentJobDefinitionsDelete(&watsonmachinelearningv4.DeploymentJobDefinitionsDeleteOptions{
+				JobDefinitionID: jobDefinition.Metadata.ID,
+				SpaceID:         core.StringPtr(plan.SpaceID.ValueString()),
+			})
+			return
+		}
+		plan.PlatformJobID = types.StringValue(platformJobID)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state deploymentJobDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	jobDefinition, response, err := wmlClient.DeploymentJobDefinitionsGet(&watsonmachinelearningv4.DeploymentJobDefinitionsGetOptions{
+		JobDefinitionID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:         core.StringPtr(state.SpaceID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Job Definition", "Could not read job definition ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	if jobDefinition.Metadata.Name != nil {
+		state.Name = types.StringValue(*jobDefinition.Metadata.Name)
+	}
+	state.Description = utils.StringValueOrNull(jobDefinition.Metadata.Description)
+	state.Rev = utils.StringValueOrNull(jobDefinition.Metadata.Rev)
+	if jobDefinition.Entity != nil {
+		if jobDefinition.Entity.Deployment != nil && jobDefinition.Entity.Deployment.ID != nil {
+			state.DeploymentID = types.StringValue(*jobDefinition.Entity.Deployment.ID)
+		}
+		state.HardwareSpec = refreshJobHardwareSpec(state.HardwareSpec, jobDefinition.Entity.HardwareSpec)
+		if jobDefinition.Entity.Scoring != nil {
+			state.InputDataReferences, state.OutputDataReference, state.EnvironmentVariables = refreshJobScoring(&state, jobDefinition.Entity.Scoring)
+		}
+	}
+
+	if state.PlatformJobID.ValueString() != "" {
+		schedule, err := r.platformJobSchedule(ctx, state.SpaceID.ValueString(), state.PlatformJobID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Getting Job Definition Schedule", "Could not read platform job ID "+state.PlatformJobID.ValueString()+": "+err.Error())
+			return
+		}
+		if schedule == "" {
+			state.PlatformJobID = types.StringNull()
+		}
+		state.Schedule = utils.If(schedule != "", types.StringValue(schedule), types.StringNull())
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state deploymentJobDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan deploymentJobDefinitionResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var jsonPatches []watsonmachinelearningv4.JSONPatchOperation
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/name"),
+			Value: core.StringPtr(plan.Name.ValueString()),
+		})
+	}
+	if plan.Description.ValueString() != state.Description.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/description"),
+			Value: core.StringPtr(plan.Description.ValueString()),
+		})
+	}
+	if !reflect.DeepEqual(plan.HardwareSpec, state.HardwareSpec) {
+		hardwareSpec := jobHardwareSpec(plan.HardwareSpec)
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr(utils.If(hardwareSpec != nil, "replace", "remove")),
+			Path:  core.StringPtr("/hardware_spec"),
+			Value: hardwareSpec,
+		})
+	}
+	if !reflect.DeepEqual(plan.InputDataReferences, state.InputDataReferences) ||
+		!reflect.DeepEqual(plan.OutputDataReference, state.OutputDataReference) ||
+		!reflect.DeepEqual(plan.EnvironmentVariables, state.EnvironmentVariables) {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/scoring"),
+			Value: jobScoringRequest(plan.InputDataReferences, plan.OutputDataReference, plan.EnvironmentVariables),
+		})
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Rev = state.Rev
+	if len(jsonPatches) > 0 {
+		_, response, err := wmlClient.DeploymentJobDefinitionsUpdate(&watsonmachinelearningv4.DeploymentJobDefinitionsUpdateOptions{
+			JobDefinitionID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:         core.StringPtr(state.SpaceID.ValueString()),
+			JSONPatch:       jsonPatches,
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Job Definition", "Could not update job definition ID "+state.ID.ValueString(), response, err) {
+			return
+		}
+
+		revision, response, err := wmlClient.DeploymentJobDefinitionsCreateRevision(&watsonmachinelearningv4.DeploymentJobDefinitionsCreateRevisionOptions{
+			JobDefinitionID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:         core.StringPtr(state.SpaceID.ValueString()),
+			CommitMessage:   core.StringPtr("Updated by Terraform"),
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Job Definition Revision", "Could not create revision of job definition ID "+state.ID.ValueString(), response, err) {
+			return
+		}
+		plan.Rev = utils.StringValueOrNull(revision.Metadata.Rev)
+	}
+
+	plan.PlatformJobID = state.PlatformJobID
+	if plan.Schedule.ValueString() != state.Schedule.ValueString() {
+		if state.PlatformJobID.ValueString() != "" {
+			err = r.deletePlatformJob(ctx, state.SpaceID.ValueString(), state.PlatformJobID.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Error Unscheduling Job Definition", "Could not delete platform job ID "+state.PlatformJobID.ValueString()+": "+err.Error())
+				return
+			}
+			plan.PlatformJobID = types.StringNull()
+		}
+		if plan.Schedule.ValueString() != "" {
+			platformJobID, err := r.createPlatformJob(ctx, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError("Error Scheduling Job Definition", "Could not schedule job definition ID "+plan.ID.ValueString()+": "+err.Error())
+				return
+			}
+			plan.PlatformJobID = types.StringValue(platformJobID)
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *deploymentJobDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state deploymentJobDefinitionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.PlatformJobID.ValueString() != "" {
+		err := r.deletePlatformJob(ctx, state.SpaceID.ValueString(), state.PlatformJobID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Unscheduling Job Definition", "Could not delete platform job ID "+state.PlatformJobID.ValueString()+": "+err.Error())
+			return
+		}
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	response, err := wmlClient.DeploymentJobDefinitionsDelete(&watsonmachinelearningv4.DeploymentJobDefinitionsDeleteOptions{
+		JobDefinitionID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:         core.StringPtr(state.SpaceID.ValueString()),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Job Definition", "Could not delete job definition ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+}
+
+func (r *deploymentJobDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.SplitN(req.ID, "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected import identifier with format <space_id>/<job_definition_id>. Got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+}
+
+// createPlatformJob creates a platform job that runs the job definition on
+// the configured schedule and returns its ID.
+func (r *deploymentJobDefinitionResource) createPlatformJob(ctx context.Context, plan *deploymentJobDefinitionResourceModel) (string, error) {
+	body := map[string]interface{}{
+		"job": map[string]interface{}{
+			"asset_ref":     plan.ID.ValueString(),
+			"name":          plan.Name.ValueString(),
+			"description":   plan.Description.ValueString(),
+			"configuration": map[string]interface{}{},
+			"schedule":      plan.Schedule.ValueString(),
+		},
+	}
+	jsonResponse, err := r.platformJobRequest(ctx, core.POST, `/v2/jobs`, nil, plan.SpaceID.ValueString(), body)
+	if err != nil {
+		return "", err
+	}
+	metadata, _ := jsonResponse["metadata"].(map[string]interface{})
+	platformJobID, _ := metadata["asset_id"].(string)
+	if platformJobID == "" {
+		return "", fmt.Errorf("platform job ID missing in response")
+	}
+	return platformJobID, nil
+}
+
+// platformJobSchedule returns the schedule of a platform job, or "" if the
+// platform job no longer exists or is not scheduled.
+func (r *deploymentJobDefinitionResource) platformJobSchedule(ctx context.Context, spaceID string, platformJobID string) (string, error) {
+	jsonResponse, err := r.platformJobRequest(ctx, core.GET, `/v2/jobs/{job_id}`, map[string]string{"job_id": platformJobID}, spaceID, nil)
+	if err != nil {
+		return "", err
+	}
+	entity, _ := jsonResponse["entity"].(map[string]interface{})
+	job, _ := entity["job"].(map[string]interface{})
+	schedule, _ := job["schedule"].(string)
+	return schedule, nil
+}
+
+func (r *deploymentJobDefinitionResource) deletePlatformJob(ctx context.Context, spaceID string, platformJobID string) error {
+	_, err := r.platformJobRequest(ctx, core.DELETE, `/v2/jobs/{job_id}`, map[string]string{"job_id": platformJobID}, spaceID, nil)
+	return err
+}
+
+func (r *deploymentJobDefinitionResource) platformJobRequest(ctx context.Context, method string, apiPath string, pathParamsMap map[string]string, spaceID string, body interface{}) (map[string]interface{}, error) {
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := core.NewRequestBuilder(method)
+	builder = builder.WithContext(ctx)
+
+	_, err = builder.ResolveRequestURL(r.client.Config.SpacesURL(), apiPath, pathParamsMap)
+	if err != nil {
+		return nil, err
+	}
+	utils.AddHeaders(builder, wmlClient.Service.DefaultHeaders)
+	builder.AddHeader("Accept", "application/json")
+	builder.AddQuery("space_id", spaceID)
+	if body != nil {
+		_, err = builder.SetBodyContentJSON(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	err = wmlClient.Service.Options.Authenticator.Authenticate(request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := wmlClient.Service.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if (method == core.DELETE || method == core.GET) && response.StatusCode == 404 {
+		return nil, nil
+	}
+	if err := utils.BodyError(response.StatusCode, content); err != nil {
+		return nil, err
+	}
+
+	var jsonResponse map[string]interface{}
+	if len(content) > 0 {
+		err = json.Unmarshal(content, &jsonResponse)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jsonResponse, nil
+}
+
+func jobHardwareSpecAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Hardware specification of the job.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "Name of hardware spec, e.g. S, M or L.",
+				Required:    true,
+			},
+			"num_nodes": schema.Int64Attribute{
+				Description: "Number of nodes.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func jobDataReferenceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			Description: "Type of data reference, e.g. data_asset, connection_asset or s3.",
+			Required:    true,
+		},
+		"connection_id": schema.StringAttribute{
+			Description: "ID of the connection asset.",
+			Optional:    true,
+		},
+		"location": schema.MapAttribute{
+			Description: "Location of the data, e.g. href, bucket, path or file_name.",
+			ElementType: types.StringType,
+			Required:    true,
+		},
+	}
+}
+
+func jobInputDataReferencesAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "Input data references of the scoring job.",
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: jobDataReferenceAttributes(),
+		},
+	}
+}
+
+func jobOutputDataReferenceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Output data reference of the scoring job.",
+		Optional:    true,
+		Attributes:  jobDataReferenceAttributes(),
+	}
+}
+
+func jobHardwareSpec(model *jobHardwareSpecModel) *watsonmachinelearningv4.HardwareSpecRel {
+	if model == nil {
+		return nil
+	}
+	return &watsonmachinelearningv4.HardwareSpecRel{
+		Name:     core.StringPtr(model.Name.ValueString()),
+		NumNodes: utils.If(!model.NumNodes.IsNull(), core.Int64Ptr(model.NumNodes.ValueInt64()), nil),
+	}
+}
+
+// refreshJobHardwareSpec returns prior with the values of hardwareSpec. The
+// hardware spec and its number of nodes stay null when they were not set, as
+// the API fills in defaults.
+func refreshJobHardwareSpec(prior *jobHardwareSpecModel, hardwareSpec *watsonmachinelearningv4.HardwareSpecRel) *jobHardwareSpecModel {
+	if prior == nil || hardwareSpec == nil {
+		return nil
+	}
+	refreshed := *prior
+	if hardwareSpec.Name != nil {
+		refreshed.Name = types.StringValue(*hardwareSpec.Name)
+	}
+	if hardwareSpec.NumNodes == nil {
+		refreshed.NumNodes = types.Int64Null()
+	} else if !prior.NumNodes.IsNull() {
+		refreshed.NumNodes = types.Int64Value(*hardwareSpec.NumNodes)
+	}
+	return &refreshed
+}
+
+// refreshJobScoring returns the data references and environment variables of
+// scoring.
+func refreshJobScoring(state *deploymentJobDefinitionResourceModel, scoring *watsonmachinelearningv4.JobScoringRequest) ([]jobDataReferenceModel, *jobDataReferenceModel, map[string]types.String) {
+	var inputs []jobDataReferenceModel
+	for _, v := range scoring.InputDataReferences {
+		inputs = append(inputs, jobDataReferenceState(v))
+	}
+	if inputs == nil && state.InputDataReferences != nil {
+		inputs = []jobDataReferenceModel{}
+	}
+
+	var output *jobDataReferenceModel
+	if scoring.OutputDataReference != nil {
+		outputDataReference := jobDataReferenceState(*scoring.OutputDataReference)
+		output = &outputDataReference
+	}
+
+	var environmentVariables map[string]types.String
+	if len(scoring.EnvironmentVariables) > 0 || state.EnvironmentVariables != nil {
+		environmentVariables = make(map[string]types.String, len(scoring.EnvironmentVariables))
+		for k, v := range scoring.EnvironmentVariables {
+			environmentVariables[k] = types.StringValue(v)
+		}
+	}
+	return inputs, output, environmentVariables
+}
+
+func jobDataReferenceState(reference watsonmachinelearningv4.DataConnectionReference) jobDataReferenceModel {
+	model := jobDataReferenceModel{
+		Type:         utils.StringValueOrNull(reference.Type),
+		ConnectionID: types.StringNull(),
+		Location:     make(map[string]types.String, len(reference.Location)),
+	}
+	if connection, ok := reference.Connection.(map[string]interface{}); ok {
+		if id, ok := connection["id"].(string); ok && id != "" {
+			model.ConnectionID = types.StringValue(id)
+		}
+	}
+	for k, v := range reference.Location {
+		model.Location[k] = types.StringValue(v)
+	}
+	return model
+}
+
+func jobDataReference(model jobDataReferenceModel) watsonmachinelearningv4.DataConnectionReference {
+	location := make(map[string]string, len(model.Location))
+	for k, v := range model.Location {
+		location[k] = v.ValueString()
+	}
+	var connection interface{}
+	if model.ConnectionID.ValueString() != "" {
+		connection = map[string]string{"id": model.ConnectionID.ValueString()}
+	}
+	return watsonmachinelearningv4.DataConnectionReference{
+		Type:       core.StringPtr(model.Type.ValueString()),
+		Connection: connection,
+		Location:   location,
+	}
+}
+
+func jobScoringRequest(inputs []jobDataReferenceModel, output *jobDataReferenceModel, environmentVariables map[string]types.String) *watsonmachinelearningv4.JobScoringRequest {
+	scoring := &watsonmachinelearningv4.JobScoringRequest{}
+	for _, v := range inputs {
+		scoring.InputDataReferences = append(scoring.InputDataReferences, jobDataReference(v))
+	}
+	if output != nil {
+		outputDataReference := jobDataReference(*output)
+		scoring.OutputDataReference = &outputDataReference
+	}
+	if len(environmentVariables) > 0 {
+		scoring.EnvironmentVariables = make(map[string]string, len(environmentVariables))
+		for k, v := range environmentVariables {
+			scoring.EnvironmentVariables[k] = v.ValueString()
+		}
+	}
+	return scoring
+}
@@ -0,0 +1,458 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &functionResource{}
+	_ resource.ResourceWithConfigure   = &functionResource{}
+	_ resource.ResourceWithImportState = &functionResource{}
+)
+
+type functionResource struct {
+	client *client.Client
+}
+
+type functionResourceModel struct {
+	ID           types.String       `tfsdk:"id"`
+	Name         types.String       `tfsdk:"name"`
+	Description  types.String       `tfsdk:"description"`
+	SoftwareSpec types.String       `tfsdk:"software_spec"`
+	CodePath     types.String       `tfsdk:"code_path"`
+	Checksum     types.String       `tfsdk:"checksum"`
+	InputSchema  []inputSchemaModel `tfsdk:"input_schema"`
+	OutputSchema []inputSchemaModel `tfsdk:"output_schema"`
+	ProjectID    types.String       `tfsdk:"project_id"`
+	SpaceID      types.String       `tfsdk:"space_id"`
+}
+
+func NewFunctionResource() resource.Resource {
+	return &functionResource{}
+}
+
+func (r *functionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *functionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function"
+}
+
+func (r *functionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	dataSchemaAttribute := func(description string) schema.ListNestedAttribute {
+		return schema.ListNestedAttribute{
+			Description: description,
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Optional: true,
+					},
+					"type": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a Python deployable function on IBM Cloud Pak for Data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for function.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of function.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of function.",
+				Optional:    true,
+			},
+			"software_spec": schema.StringAttribute{
+				Description: "Software spec of function.",
+				Required:    true,
+			},
+			"code_path": schema.StringAttribute{
+				Description: "gzip archive of the function code. Changing the path or the content uploads the code again.",
+				Required:    true,
+			},
+			"checksum": schema.StringAttribute{
+				Description: "SHA-256 checksum of the code archive. Computed from code_path when not set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					checksumFromFile("code_path"),
+				},
+			},
+			"input_schema":  dataSchemaAttribute("Input schema of function."),
+			"output_schema": dataSchemaAttribute("Output schema of function."),
+			"project_id": schema.StringAttribute{
+				Description: "Project ID of function.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID of function.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *functionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan functionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, err := functionCodeChecksum(&plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("checksum"), "Unable to Read Function Code", err.Error())
+		return
+	}
+
+	schemas, err := functionSchemas(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to parse function schemas", err.Error())
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	function, response, err := wmlClient.FunctionsCreate(&watsonmachinelearningv4.FunctionsCreateOptions{
+		Name:        core.StringPtr(plan.Name.ValueString()),
+		Description: utils.If(plan.Description.ValueString() != "", core.StringPtr(plan.Description.ValueString()), nil),
+		Type:        core.StringPtr("python"),
+		SoftwareSpec: &watsonmachinelearningv4.SoftwareSpecRel{
+			Name: core.StringPtr(plan.SoftwareSpec.ValueString()),
+		},
+		SpaceID:   utils.If(plan.SpaceID.ValueString() != "", core.StringPtr(plan.SpaceID.ValueString()), nil),
+		ProjectID: utils.If(plan.ProjectID.ValueString() != "", core.StringPtr(plan.ProjectID.ValueString()), nil),
+		Schemas:   schemas,
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Function", "Could not create function", response, err) {
+		return
+	}
+
+	plan.ID = types.StringValue(*function.Metadata.ID)
+
+	err = r.uploadCode(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Uploading Function Code", "Could not upload code to function ID "+plan.ID.ValueString()+": "+err.Error())
+		// The function is not tracked in state, delete it rather than leaving it behind.
+		response, err := wmlClient.FunctionsDelete(&watsonmachinelearningv4.FunctionsDeleteOptions{
+			FunctionID: function.Metadata.ID,
+			SpaceID:    utils.If(plan.SpaceID.ValueString() != "", core.StringPtr(plan.SpaceID.ValueString()), nil),
+			ProjectID:  utils.If(plan.SpaceID.ValueString() == "", core.StringPtr(plan.ProjectID.ValueString()), nil),
+		})
+		if err := utils.ResponseError(response, err); err != nil && !utils.IsNotFound(response) {
+			resp.Diagnostics.AddWarning("Unable to Delete Function", "Could not delete function ID "+plan.ID.ValueString()+" after the failed code upload, delete it manually: "+err.Error())
+		}
+		return
+	}
+
+	plan.Checksum = types.StringValue(checksum)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *functionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state functionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	function, response, err := wmlClient.FunctionsGet(&watsonmachinelearningv4.FunctionsGetOptions{
+		FunctionID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:    utils.If(state.SpaceID.ValueString() != "", core.StringPtr(state.SpaceID.ValueString()), nil),
+		ProjectID:  utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Function", "Could not read function ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	if function.Metadata.Name != nil {
+		state.Name = types.StringValue(*function.Metadata.Name)
+	}
+	if function.Metadata.Description != nil && *function.Metadata.Description != "" {
+		state.Description = types.StringValue(*function.Metadata.Description)
+	} else {
+		state.Description = types.StringNull()
+	}
+	if function.Entity.SoftwareSpec != nil && function.Entity.SoftwareSpec.Name != nil {
+		state.SoftwareSpec = types.StringValue(*function.Entity.SoftwareSpec.Name)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *functionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state functionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan functionResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The code is only read when it changed, so that other attributes can be
+	// updated after code_path was removed locally.
+	codeChanged := plan.CodePath.ValueString() != state.CodePath.ValueString() || plan.Checksum.IsUnknown() || plan.Checksum.ValueString() != state.Checksum.ValueString()
+	checksum := state.Checksum.ValueString()
+	if codeChanged {
+		var err error
+		checksum, err = functionCodeChecksum(&plan)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("checksum"), "Unable to Read Function Code", err.Error())
+			return
+		}
+		codeChanged = plan.CodePath.ValueString() != state.CodePath.ValueString() || checksum != state.Checksum.ValueString()
+	}
+
+	var jsonPatches []watsonmachinelearningv4.JSONPatchOperation
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/name"),
+			Value: core.StringPtr(plan.Name.ValueString()),
+		})
+	}
+	if plan.Description.ValueString() != state.Description.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/description"),
+			Value: core.StringPtr(plan.Description.ValueString()),
+		})
+	}
+	if plan.SoftwareSpec.ValueString() != state.SoftwareSpec.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:   core.StringPtr("replace"),
+			Path: core.StringPtr("/software_spec"),
+			Value: &watsonmachinelearningv4.SoftwareSpecRel{
+				Name: core.StringPtr(plan.SoftwareSpec.ValueString()),
+			},
+		})
+	}
+	if !reflect.DeepEqual(plan.InputSchema, state.InputSchema) || !reflect.DeepEqual(plan.OutputSchema, state.OutputSchema) {
+		schemas, err := functionSchemas(&plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to parse function schemas", err.Error())
+			return
+		}
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr(utils.If(schemas != nil, "replace", "remove")),
+			Path:  core.StringPtr("/schemas"),
+			Value: schemas,
+		})
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	if len(jsonPatches) > 0 {
+		_, response, err := wmlClient.FunctionsUpdate(&watsonmachinelearningv4.FunctionsUpdateOptions{
+			FunctionID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:    utils.If(state.SpaceID.ValueString() != "", core.StringPtr(state.SpaceID.ValueString()), nil),
+			ProjectID:  utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
+			JSONPatch:  jsonPatches,
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Function", "Could not update function ID "+state.ID.ValueString(), response, err) {
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	if codeChanged {
+		err = r.uploadCode(ctx, &plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Uploading Function Code", "Could not upload code to function ID "+plan.ID.ValueString()+": "+err.Error())
+			return
+		}
+	}
+	plan.Checksum = types.StringValue(checksum)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *functionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state functionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
+		return
+	}
+
+	response, err := wmlClient.FunctionsDelete(&watsonmachinelearningv4.FunctionsDeleteOptions{
+		FunctionID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:    utils.If(state.SpaceID.ValueString() != "", core.StringPtr(state.SpaceID.ValueString()), nil),
+		ProjectID:  utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Function", "Could not delete function ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+}
+
+func (r *functionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.SplitN(req.ID, "/", 3)
+	if len(idParts) != 3 || (idParts[0] != "space" && idParts[0] != "project") || idParts[1] == "" || idParts[2] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected import identifier with format space/<space_id>/<function_id> or project/<project_id>/<function_id>. Got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(idParts[0]+"_id"), idParts[1])...)
+}
+
+func (r *functionResource) uploadCode(ctx context.Context, plan *functionResourceModel) error {
+	wmlClient, err := r.client.WMLClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(plan.CodePath.ValueString())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, response, err := wmlClient.FunctionsUploadCodeWithContext(ctx, &watsonmachinelearningv4.FunctionsUploadCodeOptions{
+		FunctionID: core.StringPtr(plan.ID.ValueString()),
+		UploadCode: file,
+		SpaceID:    utils.If(plan.SpaceID.ValueString() != "", core.StringPtr(plan.SpaceID.ValueString()), nil),
+		ProjectID:  utils.If(plan.SpaceID.ValueString() == "", core.StringPtr(plan.ProjectID.ValueString()), nil),
+	})
+	return utils.ResponseError(response, err)
+}
+
+// functionCodeChecksum returns the checksum of the code archive and fails if
+// it does not match the checksum set in the configuration.
+func functionCodeChecksum(plan *functionResourceModel) (string, error) {
+	checksum, err := utils.FileChecksum(plan.CodePath.ValueString())
+	if err != nil {
+		return "", err
+	}
+	if !plan.Checksum.IsUnknown() && !plan.Checksum.IsNull() && plan.Checksum.ValueString() != checksum {
+		return "", fmt.Errorf("checksum of %s is %s, expected %s", plan.CodePath.ValueString(), checksum, plan.Checksum.ValueString())
+	}
+	return checksum, nil
+}
+
+func functionSchemas(plan *functionResourceModel) (*watsonmachinelearningv4.FunctionEntitySchemas, error) {
+	if len(plan.InputSchema) == 0 && len(plan.OutputSchema) == 0 {
+		return nil, nil
+	}
+
+	toDataSchema := func(id string, fields []inputSchemaModel) ([]watsonmachinelearningv4.DataSchema, error) {
+		if len(fields) == 0 {
+			return nil, nil
+		}
+		jsonFields := make([]inputSchemaJsonModel, len(fields))
+		for i, v := range fields {
+			jsonFields[i] = inputSchemaJsonModel{
+				Name: v.Name.ValueString(),
+				Type: v.Type.ValueString(),
+			}
+		}
+		objFields, err := json.Marshal(jsonFields)
+		if err != nil {
+			return nil, err
+		}
+		var interfaceFields []interface{}
+		err = json.Unmarshal(objFields, &interfaceFields)
+		if err != nil {
+			return nil, err
+		}
+		return []watsonmachinelearningv4.DataSchema{
+			{
+				ID:     core.StringPtr(id),
+				Fields: interfaceFields,
+			},
+		}, nil
+	}
+
+	input, err := toDataSchema("input_data_schema", plan.InputSchema)
+	if err != nil {
+		return nil, err
+	}
+	output, err := toDataSchema("output_data_schema", plan.OutputSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &watsonmachinelearningv4.FunctionEntitySchemas{
+		Input:  input,
+		Output: output,
+	}, nil
+}
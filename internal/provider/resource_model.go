@@ -6,7 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
+	"path/filepath"
+	"strconv"
 
 	"terraform-provider-ibmcpd/internal/go-sdk/client"
 	"terraform-provider-ibmcpd/internal/go-sdk/watsonmachinelearningv4"
@@ -33,17 +34,21 @@ type modelResource struct {
 }
 
 type modelResourceModel struct {
-	ID           types.String       `tfsdk:"id"`
-	Name         types.String       `tfsdk:"name"`
-	Type         types.String       `tfsdk:"type"`
-	ModelPath    types.String       `tfsdk:"model_path"`
-	InputSchema  []inputSchemaModel `tfsdk:"input_schema"`
-	LabelColumn  types.String       `tfsdk:"label_column"`
-	SoftwareSpec types.String       `tfsdk:"software_spec"`
-	ProjectID    types.String       `tfsdk:"project_id"`
-	SpaceID      types.String       `tfsdk:"space_id"`
-	AssetID      types.String       `tfsdk:"asset_id"`
-	Checksum     types.String       `tfsdk:"checksum"`
+	ID              types.String       `tfsdk:"id"`
+	Name            types.String       `tfsdk:"name"`
+	Type            types.String       `tfsdk:"type"`
+	ModelPath       types.String       `tfsdk:"model_path"`
+	InputSchema     []inputSchemaModel `tfsdk:"input_schema"`
+	LabelColumn     types.String       `tfsdk:"label_column"`
+	SoftwareSpec    types.String       `tfsdk:"software_spec"`
+	ProjectID       types.String       `tfsdk:"project_id"`
+	SpaceID         types.String       `tfsdk:"space_id"`
+	AssetID         types.String       `tfsdk:"asset_id"`
+	Checksum        types.String       `tfsdk:"checksum"`
+	CreateRevisions types.Bool         `tfsdk:"create_revisions"`
+	CleanupLocal    types.Bool         `tfsdk:"cleanup_local_file"`
+	Rev             types.String       `tfsdk:"rev"`
+	LatestRevision  types.String       `tfsdk:"latest_revision"`
 }
 
 type inputSchemaModel struct {
@@ -87,19 +92,19 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "Type of model.",
+				Description: "Type of model. Changing it creates a new model.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"software_spec": schema.StringAttribute{
 				Description: "Software spec of model.",
 				Required:    true,
 			},
 			"model_path": schema.StringAttribute{
-				Description: "tar.gz file of model from joblib.",
+				Description: "tar.gz file of model from joblib. The content is uploaded again when its checksum changes.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"input_schema": schema.ListNestedAttribute{
 				Description: "Training input schema of model.",
@@ -135,12 +140,29 @@ func (r *modelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"checksum": schema.StringAttribute{
-				Description: "Checksum of Python object.",
+				Description: "Checksum of Python object. Computed as the SHA-256 of model_path when not set. A change uploads the content again.",
 				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					checksumFromFile("model_path"),
 				},
 			},
+			"cleanup_local_file": schema.BoolAttribute{
+				Description: "Delete model_path after it was uploaded.",
+				Optional:    true,
+			},
+			"create_revisions": schema.BoolAttribute{
+				Description: "Create a new revision of model after each content upload.",
+				Optional:    true,
+			},
+			"rev": schema.StringAttribute{
+				Description: "Revision created for the current content of model. Only set when create_revisions is enabled.",
+				Computed:    true,
+			},
+			"latest_revision": schema.StringAttribute{
+				Description: "Latest revision of model. Only set when create_revisions is enabled.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -159,20 +181,22 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	plan.Rev = types.StringNull()
+	plan.LatestRevision = types.StringNull()
+
 	if plan.AssetID.ValueString() != "" && plan.SpaceID.ValueString() != "" {
 		pathParamsMap := map[string]string{
 			"asset_id": plan.AssetID.ValueString(),
 		}
 		builder := core.NewRequestBuilder(core.POST)
-		builder = builder.WithContext(context.Background())
+		builder = builder.WithContext(ctx)
 
-		url := utils.If(strings.Contains(r.client.Config.URL, "cloud.ibm.com"), "https://dataplatform.cloud.ibm.com", r.client.Config.URL)
-
-		_, err = builder.ResolveRequestURL(url, `/projects/api/rest/catalogs/assets/{asset_id}/promote`, pathParamsMap)
+		_, err = builder.ResolveRequestURL(r.client.Config.DataplatformURL(), `/projects/api/rest/catalogs/assets/{asset_id}/promote`, pathParamsMap)
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to build promote URL", err.Error())
 			return
 		}
+		utils.AddHeaders(builder, wmlClient.Service.DefaultHeaders)
 		builder.AddHeader("Content-Type", "application/json")
 		builder.AddQuery("project_id", plan.ProjectID.ValueString())
 
@@ -205,42 +229,31 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 			resp.Diagnostics.AddError("Error reading from HTTP response", err.Error())
 			return
 		}
-		var jsonResponse map[string]interface{}
+		if err := utils.BodyError(response.StatusCode, content); err != nil {
+			resp.Diagnostics.AddError("Unable to promote model", "Could not promote asset ID "+plan.AssetID.ValueString()+", "+err.Error())
+			return
+		}
+		var jsonResponse struct {
+			PromotedAsset struct {
+				AssetID string `json:"asset_id"`
+			} `json:"promotedAsset"`
+		}
 		err = json.Unmarshal(content, &jsonResponse)
 		if err != nil {
 			resp.Diagnostics.AddError("Error parsing response json", err.Error())
 			return
 		}
-		if jsonResponse == nil {
-			resp.Diagnostics.AddError("Unable to promote model", err.Error())
+		if jsonResponse.PromotedAsset.AssetID == "" {
+			resp.Diagnostics.AddError("Unable to promote model", "Promoted asset ID missing in response.")
 			return
 		}
-		plan.ID = types.StringValue(jsonResponse["promotedAsset"].(map[string]interface{})["asset_id"].(string))
+		plan.ID = types.StringValue(jsonResponse.PromotedAsset.AssetID)
+		plan.Checksum = utils.If(plan.Checksum.IsUnknown(), types.StringNull(), plan.Checksum)
 	} else {
-		var schemas = &watsonmachinelearningv4.ModelEntitySchemas{}
-		if len(plan.InputSchema) > 0 {
-			var jsonInputSchema []interface{}
-			inputSchema := make([]inputSchemaJsonModel, len(plan.InputSchema))
-			for i, v := range plan.InputSchema {
-				inputSchema[i] = inputSchemaJsonModel{
-					Name: v.Name.ValueString(),
-					Type: v.Type.ValueString(),
-				}
-			}
-			objInputSchema, err := json.Marshal(inputSchema)
-			if err != nil {
-				resp.Diagnostics.AddError("Unable to get parse input schema", err.Error())
-				return
-			}
-			json.Unmarshal(objInputSchema, &jsonInputSchema)
-			schemas = &watsonmachinelearningv4.ModelEntitySchemas{
-				Input: []watsonmachinelearningv4.DataSchema{
-					{
-						ID:     core.StringPtr("input_data_schema"),
-						Fields: jsonInputSchema,
-					},
-				},
-			}
+		schemas, err := modelSchemas(plan.InputSchema)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to get parse input schema", err.Error())
+			return
 		}
 		model, response, err := wmlClient.ModelsCreate(&watsonmachinelearningv4.ModelsCreateOptions{
 			Name: core.StringPtr(plan.Name.ValueString()),
@@ -253,12 +266,13 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 			LabelColumn: core.StringPtr(plan.LabelColumn.ValueString()),
 			Schemas:     schemas,
 		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Creating Model", "Could not create model, unexpected error: "+err.Error())
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Model", "Could not create model", response, err) {
 			return
 		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Create model", err.Error())
+
+		checksum, _, err := plannedFileChecksum(plan.ModelPath.ValueString(), plan.Checksum, types.StringNull())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Read Model File", err.Error())
 			return
 		}
 
@@ -275,20 +289,24 @@ func (r *modelResource) Create(ctx context.Context, req resource.CreateRequest,
 			ProjectID:     utils.If(plan.ProjectID.ValueString() != "", core.StringPtr(plan.ProjectID.ValueString()), nil),
 			Body:          file,
 		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Creating Model", "Could not create model, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Create model", err.Error())
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Uploading Model Content", "Could not upload content of model ID "+*model.Metadata.ID, response, err) {
 			return
 		}
 
 		plan.ID = types.StringValue(*model.Metadata.ID)
 
-		if _, err := os.Stat(plan.ModelPath.ValueString()); err == nil || os.IsExist(err) {
-			os.Remove(plan.ModelPath.ValueString())
+		if plan.CreateRevisions.ValueBool() {
+			rev, err := createModelRevision(wmlClient, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError("Error Creating Model Revision", "Could not create revision of model ID "+plan.ID.ValueString()+": "+err.Error())
+				return
+			}
+			plan.Rev = types.StringValue(rev)
+			plan.LatestRevision = types.StringValue(rev)
 		}
+
+		plan.Checksum = types.StringValue(checksum)
+		removeLocalFile(plan.CleanupLocal, plan.ModelPath.ValueString())
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -322,16 +340,22 @@ func (r *modelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		SpaceID:   utils.If(state.SpaceID.ValueString() != "", core.StringPtr(state.SpaceID.ValueString()), nil),
 		ProjectID: utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
 	})
-	if utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		state.ID = types.StringValue(*model.Metadata.ID)
-		diags = resp.State.Set(ctx, &state)
-	} else if response.StatusCode == 404 {
-		diags = resp.State.Set(ctx, &modelResourceModel{})
-	} else {
-		resp.Diagnostics.AddError("Error Getting Model", "Could not read Model ID "+state.ID.ValueString()+". Error: "+err.Error())
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Model", "Could not read Model ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 
+	state.ID = types.StringValue(*model.Metadata.ID)
+	setModelState(&state, model)
+	if state.CreateRevisions.ValueBool() {
+		latestRevision, err := modelLatestRevision(ctx, wmlClient, &state)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Listing Model Revisions", "Could not list revisions of model ID "+state.ID.ValueString()+": "+err.Error())
+			return
+		}
+		state.LatestRevision = utils.If(latestRevision != "", types.StringValue(latestRevision), types.StringNull())
+	}
+
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -380,6 +404,25 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			}
 		}
 	}
+	if plan.LabelColumn.ValueString() != state.LabelColumn.ValueString() {
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/label_column"),
+			Value: core.StringPtr(plan.LabelColumn.ValueString()),
+		})
+	}
+	if !inputSchemaEqual(plan.InputSchema, state.InputSchema) {
+		schemas, err := modelSchemas(plan.InputSchema)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to get parse input schema", err.Error())
+			return
+		}
+		jsonPatches = append(jsonPatches, watsonmachinelearningv4.JSONPatchOperation{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/schemas"),
+			Value: schemas,
+		})
+	}
 
 	wmlClient, err := r.client.WMLClient(ctx)
 	if err != nil {
@@ -395,17 +438,22 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			JSONPatch: jsonPatches,
 		})
 
-		if err != nil {
-			resp.Diagnostics.AddError("Error Updating Model", "Could not update model id "+plan.ID.ValueString()+". Error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Update Model", err.Error())
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Model", "Could not update model id "+plan.ID.ValueString(), response, err) {
 			return
 		}
 	}
 
-	if plan.ModelPath.ValueString() != state.ModelPath.ValueString() {
+	plan.Rev = state.Rev
+	plan.LatestRevision = state.LatestRevision
+
+	checksum, contentChanged, err := plannedFileChecksum(plan.ModelPath.ValueString(), plan.Checksum, state.Checksum)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Model File", err.Error())
+		return
+	}
+	plan.Checksum = types.StringValue(checksum)
+
+	if contentChanged {
 		file, err := os.Open(plan.ModelPath.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to Read Model File", err.Error())
@@ -419,17 +467,19 @@ func (r *modelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			ProjectID:     utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
 			Body:          file,
 		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Uoload Content to Model", err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Error Uoload Content to Model", err.Error())
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Uploading Model Content", "Could not upload content of model ID "+plan.ID.ValueString(), response, err) {
 			return
 		}
-		if _, err := os.Stat(plan.ModelPath.ValueString()); err == nil || os.IsExist(err) {
-			os.Remove(plan.ModelPath.ValueString())
+		if plan.CreateRevisions.ValueBool() {
+			rev, err := createModelRevision(wmlClient, &plan)
+			if err != nil {
+				resp.Diagnostics.AddError("Error Creating Model Revision", "Could not create revision of model ID "+plan.ID.ValueString()+": "+err.Error())
+				return
+			}
+			plan.Rev = types.StringValue(rev)
+			plan.LatestRevision = types.StringValue(rev)
 		}
+		removeLocalFile(plan.CleanupLocal, plan.ModelPath.ValueString())
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -458,12 +508,7 @@ func (r *modelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		SpaceID:   utils.If(state.SpaceID.ValueString() != "", core.StringPtr(state.SpaceID.ValueString()), nil),
 		ProjectID: utils.If(state.SpaceID.ValueString() == "", core.StringPtr(state.ProjectID.ValueString()), nil),
 	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Model", "Could not delete model ID "+state.ID.ValueString()+". Error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Error Deleting Model", "Could not delete model ID "+state.ID.ValueString()+". Error: "+err.Error())
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Model", "Could not delete model ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 }
@@ -471,3 +516,129 @@ func (r *modelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 func (r *modelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+func createModelRevision(wmlClient *watsonmachinelearningv4.WatsonMachineLearningV4, model *modelResourceModel) (string, error) {
+	revision, response, err := wmlClient.ModelsCreateRevision(&watsonmachinelearningv4.ModelsCreateRevisionOptions{
+		ModelID:       core.StringPtr(model.ID.ValueString()),
+		SpaceID:       utils.If(model.SpaceID.ValueString() != "", core.StringPtr(model.SpaceID.ValueString()), nil),
+		ProjectID:     utils.If(model.SpaceID.ValueString() == "", core.StringPtr(model.ProjectID.ValueString()), nil),
+		CommitMessage: core.StringPtr("Uploaded " + filepath.Base(model.ModelPath.ValueString())),
+	})
+	if err := utils.ResponseError(response, err); err != nil {
+		return "", err
+	}
+	if revision.Metadata == nil || revision.Metadata.Rev == nil {
+		return "", fmt.Errorf("revision missing in response")
+	}
+	return *revision.Metadata.Rev, nil
+}
+
+func modelLatestRevision(ctx context.Context, wmlClient *watsonmachinelearningv4.WatsonMachineLearningV4, model *modelResourceModel) (string, error) {
+	pager, err := wmlClient.NewModelsListRevisionsPager(&watsonmachinelearningv4.ModelsListRevisionsOptions{
+		ModelID:   core.StringPtr(model.ID.ValueString()),
+		SpaceID:   utils.If(model.SpaceID.ValueString() != "", core.StringPtr(model.SpaceID.ValueString()), nil),
+		ProjectID: utils.If(model.SpaceID.ValueString() == "", core.StringPtr(model.ProjectID.ValueString()), nil),
+	})
+	if err != nil {
+		return "", err
+	}
+	revisions, err := pager.GetAllWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var latestRevision string
+	var latest int64 = -1
+	for _, v := range revisions {
+		if v.Metadata == nil || v.Metadata.Rev == nil {
+			continue
+		}
+		rev, err := strconv.ParseInt(*v.Metadata.Rev, 10, 64)
+		if err == nil && rev > latest {
+			latest = rev
+			latestRevision = *v.Metadata.Rev
+		}
+	}
+	return latestRevision, nil
+}
+
+// modelSchemas returns the schemas of a model with the given training input
+// schema.
+func modelSchemas(inputSchema []inputSchemaModel) (*watsonmachinelearningv4.ModelEntitySchemas, error) {
+	schemas := &watsonmachinelearningv4.ModelEntitySchemas{}
+	if len(inputSchema) == 0 {
+		return schemas, nil
+	}
+	fields := make([]inputSchemaJsonModel, len(inputSchema))
+	for i, v := range inputSchema {
+		fields[i] = inputSchemaJsonModel{
+			Name: v.Name.ValueString(),
+			Type: v.Type.ValueString(),
+		}
+	}
+	objInputSchema, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var jsonInputSchema []interface{}
+	if err := json.Unmarshal(objInputSchema, &jsonInputSchema); err != nil {
+		return nil, err
+	}
+	schemas.Input = []watsonmachinelearningv4.DataSchema{
+		{
+			ID:     core.StringPtr("input_data_schema"),
+			Fields: jsonInputSchema,
+		},
+	}
+	return schemas, nil
+}
+
+func inputSchemaEqual(a, b []inputSchemaModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Name.Equal(b[i].Name) || !a[i].Type.Equal(b[i].Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// setModelState refreshes the configurable attributes of state from model.
+func setModelState(state *modelResourceModel, model *watsonmachinelearningv4.ModelResource) {
+	if model.Metadata.Name != nil {
+		state.Name = types.StringValue(*model.Metadata.Name)
+	}
+	if model.Entity == nil {
+		return
+	}
+	if model.Entity.Type != nil {
+		state.Type = types.StringValue(*model.Entity.Type)
+	}
+	// The software spec is returned by ID unless the API resolved its name.
+	if model.Entity.SoftwareSpec != nil && model.Entity.SoftwareSpec.Name != nil {
+		state.SoftwareSpec = types.StringValue(*model.Entity.SoftwareSpec.Name)
+	}
+	state.LabelColumn = utils.RefreshOptionalString(state.LabelColumn, model.Entity.LabelColumn)
+
+	if state.InputSchema == nil {
+		return
+	}
+	inputSchema := []inputSchemaModel{}
+	if model.Entity.Schemas != nil && len(model.Entity.Schemas.Input) > 0 {
+		for _, field := range model.Entity.Schemas.Input[0].Fields {
+			field, ok := field.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := field["name"].(string)
+			fieldType, _ := field["type"].(string)
+			inputSchema = append(inputSchema, inputSchemaModel{
+				Name: types.StringValue(name),
+				Type: types.StringValue(fieldType),
+			})
+		}
+	}
+	state.InputSchema = inputSchema
+}
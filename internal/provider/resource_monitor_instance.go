@@ -127,12 +127,21 @@ func (r *monitorInstanceResource) Schema(_ context.Context, _ resource.SchemaReq
 			},
 			"data_mart_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"subscription_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"monitor_definition_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"drift_archive_path": schema.StringAttribute{
 				Optional: true,
@@ -229,68 +238,10 @@ func (r *monitorInstanceResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	var jsonParameters map[string]interface{}
-
-	switch plan.MonitorDefinitionID.ValueString() {
-	case "quality":
-		qualityParameters, err := json.Marshal(parametersQuality{
-			MinFeedbackDataSize: plan.Parameters.MinFeedbackDataSize.ValueInt64(),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Parsing Quality Parameters", "Could not parse quality parameters, unexpected error: "+err.Error())
-			return
-		}
-		json.Unmarshal(qualityParameters, &jsonParameters)
-
-	case "drift":
-		driftThreshold, _ := plan.Parameters.DriftThreshold.ValueBigFloat().Float64()
-		driftParameters, err := json.Marshal(parametersDrift{
-			MinSamples:       plan.Parameters.MinSamples.ValueInt64(),
-			DriftThreshold:   driftThreshold,
-			TrainDriftModel:  plan.Parameters.TrainDriftModel.ValueBool(),
-			EnableModelDrift: plan.Parameters.EnableModelDrift.ValueBool(),
-			EnableDataDrift:  plan.Parameters.EnableDataDrift.ValueBool(),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Parsing Drift Parameters", "Could not parse drift parameters, unexpected error: "+err.Error())
-			return
-		}
-		json.Unmarshal(driftParameters, &jsonParameters)
-
-	case "fairness":
-		features := make([]parametersFairnessFeature, len(plan.Parameters.Features))
-		for i, v := range plan.Parameters.Features {
-			fairnessThreshold, _ := v.Threshold.ValueBigFloat().Float64()
-			features[i] = parametersFairnessFeature{
-				Feature:   v.Feature.ValueString(),
-				Majority:  utils.ConvertString(v.Majority),
-				Minority:  utils.ConvertString(v.Minority),
-				Threshold: fairnessThreshold,
-			}
-		}
-
-		fairnessParameters, err := json.Marshal(parametersFairness{
-			Features:          features,
-			FavourableClass:   utils.ConvertString(plan.Parameters.FavourableClass),
-			UnfavourableClass: utils.ConvertString(plan.Parameters.UnfavourableClass),
-			MinRecords:        plan.Parameters.MinRecords.ValueInt64(),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Parsing Drift Parameters", "Could not parse drift parameters, unexpected error: "+err.Error())
-			return
-		}
-		json.Unmarshal(fairnessParameters, &jsonParameters)
-
-	case "explainability":
-		explainabilityParameters, err := json.Marshal(parametersExplainability{
-			Enabled: plan.Parameters.Enabled.ValueBool(),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Parsing Quality Parameters", "Could not parse quality parameters, unexpected error: "+err.Error())
-			return
-		}
-		json.Unmarshal(explainabilityParameters, &jsonParameters)
-
+	jsonParameters, err := monitorParameters(plan.MonitorDefinitionID.ValueString(), plan.Parameters)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Parsing Monitor Parameters", "Could not parse "+plan.MonitorDefinitionID.ValueString()+" parameters, unexpected error: "+err.Error())
+		return
 	}
 
 	thresholds := make([]watsonopenscalev2.MetricThresholdOverride, len(plan.Thresholds))
@@ -318,12 +269,7 @@ func (r *monitorInstanceResource) Create(ctx context.Context, req resource.Creat
 			EnableDataDrift:  core.BoolPtr(true),
 			EnableModelDrift: core.BoolPtr(true),
 		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Uploading Drift Archive", "Could not upload drift archive, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Upload Drift Archive", err.Error())
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Uploading Drift Archive", "Could not upload drift archive", response, err) {
 			return
 		}
 	}
@@ -339,12 +285,7 @@ func (r *monitorInstanceResource) Create(ctx context.Context, req resource.Creat
 		Thresholds: utils.If(plan.MonitorDefinitionID.ValueString() != "mrm", thresholds, nil),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Creating Instance", "Could not create instance, unexpected error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Create Instance", err.Error())
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Instance", "Could not create instance", response, err) {
 		return
 	}
 
@@ -367,23 +308,21 @@ func (r *monitorInstanceResource) Read(ctx context.Context, req resource.ReadReq
 
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
 	monitorInstance, response, err := wosClient.InstancesGet(&watsonopenscalev2.InstancesGetOptions{
 		MonitorInstanceID: core.StringPtr(state.ID.ValueString()),
 	})
-	if utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		state.ID = types.StringValue(*monitorInstance.Metadata.ID)
-		state.MonitorDefinitionID = types.StringValue(*monitorInstance.Entity.MonitorDefinitionID)
-		diags = resp.State.Set(ctx, &state)
-	} else if response.StatusCode == 404 {
-		diags = resp.State.Set(ctx, &monitorInstanceResourceModel{})
-	} else {
-		resp.Diagnostics.AddError("Error Getting Monitor Instance", "Could not read Monitor Instance ID "+state.ID.ValueString()+": "+err.Error())
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Monitor Instance", "Could not read Monitor Instance ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 
+	state.ID = types.StringValue(*monitorInstance.Metadata.ID)
+	setMonitorInstanceState(&state, monitorInstance.Entity)
+
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -410,7 +349,8 @@ func (r *monitorInstanceResource) Update(ctx context.Context, req resource.Updat
 
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
 	var jsonPatches []watsonopenscalev2.PatchDocument
@@ -437,6 +377,18 @@ func (r *monitorInstanceResource) Update(ctx context.Context, req resource.Updat
 		})
 
 	}
+	if !reflect.DeepEqual(plan.Parameters, state.Parameters) && plan.MonitorDefinitionID.ValueString() != "mrm" {
+		parameters, err := monitorParameters(plan.MonitorDefinitionID.ValueString(), plan.Parameters)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Parsing Monitor Parameters", "Could not parse "+plan.MonitorDefinitionID.ValueString()+" parameters, unexpected error: "+err.Error())
+			return
+		}
+		jsonPatches = append(jsonPatches, watsonopenscalev2.PatchDocument{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/parameters"),
+			Value: parameters,
+		})
+	}
 	// for _, field := range updateableFields {
 	// 	if utils.GetAttr(&plan, field).Interface().(types.String).ValueString() != utils.GetAttr(&state, field).Interface().(types.String).ValueString() {
 	// 		switch field {
@@ -473,21 +425,18 @@ func (r *monitorInstanceResource) Update(ctx context.Context, req resource.Updat
 	// 	}
 	// }
 
-	result, response, err := wosClient.InstancesUpdate(&watsonopenscalev2.InstancesUpdateOptions{
-		MonitorInstanceID: core.StringPtr(plan.ID.ValueString()),
-		PatchDocument:     jsonPatches,
-	})
+	if len(jsonPatches) > 0 {
+		_, response, err := wosClient.InstancesUpdate(&watsonopenscalev2.InstancesUpdateOptions{
+			MonitorInstanceID: core.StringPtr(state.ID.ValueString()),
+			PatchDocument:     jsonPatches,
+		})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Updating Monitor Instance", "Could not update monitor instance ID "+plan.ID.ValueString()+": "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Update Monitor Instance", err.Error())
-		return
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Monitor Instance", "Could not update monitor instance ID "+state.ID.ValueString(), response, err) {
+			return
+		}
 	}
 
-	plan.ID = types.StringValue(*result.Metadata.ID)
+	plan.ID = state.ID
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -506,19 +455,15 @@ func (r *monitorInstanceResource) Delete(ctx context.Context, req resource.Delet
 
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
 	response, err := wosClient.InstancesDelete(&watsonopenscalev2.InstancesDeleteOptions{
 		MonitorInstanceID: core.StringPtr(state.ID.ValueString()),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Monitor Instance", "Could not delete monitor instance ID "+state.ID.ValueString()+": "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Delete Monitor Instance", err.Error())
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Monitor Instance", "Could not delete monitor instance ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 }
@@ -526,3 +471,189 @@ func (r *monitorInstanceResource) Delete(ctx context.Context, req resource.Delet
 func (r *monitorInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// setMonitorInstanceState refreshes the configurable attributes of state from
+// entity. Parameters and thresholds are only refreshed when they are set in
+// state, since OpenScale fills in defaults for omitted values.
+func setMonitorInstanceState(state *monitorInstanceResourceModel, entity *watsonopenscalev2.MonitorInstanceResponseEntity) {
+	if entity == nil {
+		return
+	}
+	if entity.DataMartID != nil {
+		state.DataMartID = types.StringValue(*entity.DataMartID)
+	}
+	if entity.MonitorDefinitionID != nil {
+		state.MonitorDefinitionID = types.StringValue(*entity.MonitorDefinitionID)
+	}
+	if entity.Target != nil && entity.Target.TargetID != nil {
+		state.SubscriptionID = types.StringValue(*entity.Target.TargetID)
+	}
+
+	if state.Parameters != nil {
+		state.Parameters = monitorParametersState(state.MonitorDefinitionID.ValueString(), entity.Parameters, state.Parameters)
+	}
+
+	if state.Thresholds != nil {
+		thresholds := make([]thresholdsModel, len(entity.Thresholds))
+		for i, v := range entity.Thresholds {
+			prior := types.NumberNull()
+			if i < len(state.Thresholds) {
+				prior = state.Thresholds[i].Value
+			}
+			thresholds[i] = thresholdsModel{
+				MetricID: utils.StringValueOrNull(v.MetricID),
+				Type:     utils.StringValueOrNull(v.Type),
+				Value:    utils.If(v.Value != nil, utils.RefreshNumber(prior, *v.Value), types.NumberNull()),
+			}
+		}
+		state.Thresholds = thresholds
+	}
+}
+
+// monitorParameters returns the API parameters of a monitor instance of the
+// given monitor definition.
+func monitorParameters(monitorDefinitionID string, parameters *parametersModel) (map[string]interface{}, error) {
+	jsonParameters := map[string]interface{}{}
+	if parameters == nil {
+		return jsonParameters, nil
+	}
+
+	var value interface{}
+	switch monitorDefinitionID {
+	case "quality":
+		value = parametersQuality{
+			MinFeedbackDataSize: parameters.MinFeedbackDataSize.ValueInt64(),
+		}
+
+	case "drift":
+		driftThreshold, _ := parameters.DriftThreshold.ValueBigFloat().Float64()
+		value = parametersDrift{
+			MinSamples:       parameters.MinSamples.ValueInt64(),
+			DriftThreshold:   driftThreshold,
+			TrainDriftModel:  parameters.TrainDriftModel.ValueBool(),
+			EnableModelDrift: parameters.EnableModelDrift.ValueBool(),
+			EnableDataDrift:  parameters.EnableDataDrift.ValueBool(),
+		}
+
+	case "fairness":
+		features := make([]parametersFairnessFeature, len(parameters.Features))
+		for i, v := range parameters.Features {
+			fairnessThreshold, _ := v.Threshold.ValueBigFloat().Float64()
+			features[i] = parametersFairnessFeature{
+				Feature:   v.Feature.ValueString(),
+				Majority:  utils.ConvertString(v.Majority),
+				Minority:  utils.ConvertString(v.Minority),
+				Threshold: fairnessThreshold,
+			}
+		}
+		value = parametersFairness{
+			Features:          features,
+			FavourableClass:   utils.ConvertString(parameters.FavourableClass),
+			UnfavourableClass: utils.ConvertString(parameters.UnfavourableClass),
+			MinRecords:        parameters.MinRecords.ValueInt64(),
+		}
+
+	case "explainability":
+		value = parametersExplainability{
+			Enabled: parameters.Enabled.ValueBool(),
+		}
+
+	default:
+		return jsonParameters, nil
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &jsonParameters); err != nil {
+		return nil, err
+	}
+	return jsonParameters, nil
+}
+
+// monitorParametersState returns prior with the values of parameters that are
+// set in prior replaced by the values returned for the monitor definition.
+func monitorParametersState(monitorDefinitionID string, parameters map[string]interface{}, prior *parametersModel) *parametersModel {
+	result := *prior
+	content, err := json.Marshal(parameters)
+	if err != nil {
+		return prior
+	}
+	has := func(key string) bool {
+		_, ok := parameters[key]
+		return ok
+	}
+
+	switch monitorDefinitionID {
+	case "quality":
+		var quality parametersQuality
+		if json.Unmarshal(content, &quality) != nil {
+			return prior
+		}
+		if !prior.MinFeedbackDataSize.IsNull() && has("min_feedback_data_size") {
+			result.MinFeedbackDataSize = types.Int64Value(quality.MinFeedbackDataSize)
+		}
+	case "drift":
+		var drift parametersDrift
+		if json.Unmarshal(content, &drift) != nil {
+			return prior
+		}
+		if !prior.MinSamples.IsNull() && has("min_samples") {
+			result.MinSamples = types.Int64Value(drift.MinSamples)
+		}
+		if !prior.DriftThreshold.IsNull() && has("drift_threshold") {
+			result.DriftThreshold = utils.RefreshNumber(prior.DriftThreshold, drift.DriftThreshold)
+		}
+		if !prior.TrainDriftModel.IsNull() && has("train_drift_model") {
+			result.TrainDriftModel = types.BoolValue(drift.TrainDriftModel)
+		}
+		if !prior.EnableModelDrift.IsNull() && has("enable_model_drift") {
+			result.EnableModelDrift = types.BoolValue(drift.EnableModelDrift)
+		}
+		if !prior.EnableDataDrift.IsNull() && has("enable_data_drift") {
+			result.EnableDataDrift = types.BoolValue(drift.EnableDataDrift)
+		}
+	case "fairness":
+		// Numeric groups are returned as ranges, which cannot be represented
+		// in state; keep prior values in that case.
+		var fairness parametersFairness
+		if json.Unmarshal(content, &fairness) != nil {
+			return prior
+		}
+		if prior.Features != nil {
+			features := make([]fairnessFeatureModel, len(fairness.Features))
+			for i, v := range fairness.Features {
+				threshold := types.NumberNull()
+				if i < len(prior.Features) {
+					threshold = prior.Features[i].Threshold
+				}
+				features[i] = fairnessFeatureModel{
+					Feature:   types.StringValue(v.Feature),
+					Majority:  utils.StringList(v.Majority),
+					Minority:  utils.StringList(v.Minority),
+					Threshold: utils.RefreshNumber(threshold, v.Threshold),
+				}
+			}
+			result.Features = features
+		}
+		if prior.FavourableClass != nil {
+			result.FavourableClass = utils.StringList(fairness.FavourableClass)
+		}
+		if prior.UnfavourableClass != nil {
+			result.UnfavourableClass = utils.StringList(fairness.UnfavourableClass)
+		}
+		if !prior.MinRecords.IsNull() && has("min_records") {
+			result.MinRecords = types.Int64Value(fairness.MinRecords)
+		}
+	case "explainability":
+		var explainability parametersExplainability
+		if json.Unmarshal(content, &explainability) != nil {
+			return prior
+		}
+		if !prior.Enabled.IsNull() && has("enabled") {
+			result.Enabled = types.BoolValue(explainability.Enabled)
+		}
+	}
+	return &result
+}
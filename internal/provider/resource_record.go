@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"time"
@@ -11,12 +12,16 @@ import (
 	"terraform-provider-ibmcpd/internal/go-sdk/watsonopenscalev2"
 	"terraform-provider-ibmcpd/internal/utils"
 
-	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+const DEFAULT_TIMEOUT_RECORD = 5 * time.Minute
+
 var (
 	_ resource.Resource              = &recordResource{}
 	_ resource.ResourceWithConfigure = &recordResource{}
@@ -27,9 +32,13 @@ type recordResource struct {
 }
 
 type recordResourceModel struct {
-	SubscriptionID types.String `tfsdk:"subscription_id"`
-	FilePath       types.String `tfsdk:"file_path"`
-	Type           types.String `tfsdk:"type"`
+	SubscriptionID   types.String `tfsdk:"subscription_id"`
+	FilePath         types.String `tfsdk:"file_path"`
+	Checksum         types.String `tfsdk:"checksum"`
+	Type             types.String `tfsdk:"type"`
+	CleanupLocalFile types.Bool   `tfsdk:"cleanup_local_file"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewRecordResource() resource.Resource {
@@ -47,7 +56,7 @@ func (r *recordResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_record"
 }
 
-func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *recordResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"subscription_id": schema.StringAttribute{
@@ -56,9 +65,26 @@ func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"file_path": schema.StringAttribute{
 				Required: true,
 			},
+			"checksum": schema.StringAttribute{
+				Description: "SHA-256 checksum of file_path. A change adds the records again.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					checksumFromFile("file_path"),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 			"type": schema.StringAttribute{
 				Required: true,
 			},
+			"cleanup_local_file": schema.BoolAttribute{
+				Description: "Delete file_path after the records were added.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
@@ -71,12 +97,25 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_RECORD)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
 		return
 	}
 
+	checksum, _, err := plannedFileChecksum(plan.FilePath.ValueString(), plan.Checksum, types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read record file", err.Error())
+		return
+	}
+	plan.Checksum = types.StringValue(checksum)
+
 	file, err := os.Open(plan.FilePath.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to open record file", err.Error())
@@ -88,46 +127,19 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		resp.Diagnostics.AddError("Unable to read record file", err.Error())
 		return
 	}
-	var jsonFeedback map[string][]interface{}
-	json.Unmarshal(content, &jsonFeedback)
-	fields := make([]string, len(jsonFeedback["fields"]))
-	for i, v := range jsonFeedback["fields"] {
-		fields[i] = v.(string)
-	}
-	objValues, err := json.Marshal(jsonFeedback["values"])
+	fields, values, err := decodeFieldsValues(content)
 	if err != nil {
-		panic(err)
-	}
-	var values [][]interface{}
-	json.Unmarshal(objValues, &values)
-
-	var dataSetID *string
-
-	for i := 1; i < 20; i++ {
-		time.Sleep(5 * time.Second)
-		resultDataSets, response, err := wosClient.DataSetsList(&watsonopenscalev2.DataSetsListOptions{
-			TargetTargetID:   core.StringPtr(plan.SubscriptionID.ValueString()),
-			Type:             core.StringPtr(plan.Type.ValueString()),
-			TargetTargetType: core.StringPtr("subscription"),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Listing Datasets", "Could not list datasets, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to List Datasets", err.Error())
-			return
-		}
-		if len(resultDataSets.DataSets) > 0 {
-			dataSetID = resultDataSets.DataSets[0].Metadata.ID
-			if *resultDataSets.DataSets[0].Entity.Status.State == "active" {
-				break
-			}
-		}
+		resp.Diagnostics.AddError("Unable to parse record file", err.Error())
+		return
+	}
+
+	dataSet, err := waitForDataSet(ctx, wosClient, plan.SubscriptionID.ValueString(), plan.Type.ValueString(), createTimeout)
+	if !utils.CheckWait(&resp.Diagnostics, "Error Waiting for Dataset", plan.Type.ValueString()+" dataset of subscription ID "+plan.SubscriptionID.ValueString()+" is not active", err) {
+		return
 	}
 
 	_, response, err := wosClient.RecordsAdd(&watsonopenscalev2.RecordsAddOptions{
-		DataSetID: dataSetID,
+		DataSetID: dataSet.Metadata.ID,
 		DatasetRecordsPayloadItem: []watsonopenscalev2.DatasetRecordsPayloadItemIntf{
 			&watsonopenscalev2.DatasetRecordsPayloadItemJsList{
 				Fields: fields,
@@ -135,18 +147,11 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 			},
 		},
 	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Adding Records", "Could not add records, unexpected error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to List Datasets", err.Error())
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Adding Records", "Could not add records", response, err) {
 		return
 	}
 
-	if _, err := os.Stat(plan.FilePath.ValueString()); err == nil || os.IsExist(err) {
-		os.Remove(plan.FilePath.ValueString())
-	}
+	removeLocalFile(plan.CleanupLocalFile, plan.FilePath.ValueString())
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -160,9 +165,44 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state recordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan recordResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Records are only added again when the checksum changes, which replaces
+	// the resource.
+	plan.Checksum = state.Checksum
 
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 }
 
 func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 
 }
+
+// decodeFieldsValues decodes a JSON document with a list of field names and a
+// list of rows of values, as used by payload and feedback files.
+func decodeFieldsValues(content []byte) ([]string, [][]interface{}, error) {
+	var document struct {
+		Fields []string        `json:"fields"`
+		Values [][]interface{} `json:"values"`
+	}
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, nil, fmt.Errorf("expected a JSON object with fields and values: %w", err)
+	}
+	return document.Fields, document.Values, nil
+}
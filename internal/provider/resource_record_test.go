@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeFieldsValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantFields []string
+		wantValues [][]interface{}
+		wantErr    string
+	}{
+		{
+			name:       "fields and values",
+			content:    `{"fields": ["age", "sex", "approved"], "values": [[35, "female", true], [41.5, null, false]]}`,
+			wantFields: []string{"age", "sex", "approved"},
+			wantValues: [][]interface{}{{35.0, "female", true}, {41.5, nil, false}},
+		},
+		{
+			name:       "no values",
+			content:    `{"fields": ["age"]}`,
+			wantFields: []string{"age"},
+		},
+		{
+			name:    "list",
+			content: `[[35, "female"]]`,
+			wantErr: "expected a JSON object with fields and values",
+		},
+		{
+			name:    "invalid",
+			content: `{"fields": ["age"], "values": [35]}`,
+			wantErr: "expected a JSON object with fields and values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, values, err := decodeFieldsValues([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeFieldsValues() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeFieldsValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("decodeFieldsValues() fields = %#v, want %#v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("decodeFieldsValues() values = %#v, want %#v", values, tt.wantValues)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"strings"
 
 	"terraform-provider-ibmcpd/internal/go-sdk/client"
 	"terraform-provider-ibmcpd/internal/go-sdk/watsonopenscalev2"
@@ -126,7 +125,13 @@ func (r *serviceProviderResource) Create(ctx context.Context, req resource.Creat
 
 	var credentials watsonopenscalev2.MLCredentialsIntf
 
-	if strings.Contains(plan.Url.ValueString(), "cloud.ibm.com") {
+	if r.client.Config.IsPublicCloud() {
+		credentials = &watsonopenscalev2.WMLCredentialsCloud{
+			Apikey:     core.StringPtr(plan.APIKey.ValueString()),
+			URL:        core.StringPtr(plan.Url.ValueString()),
+			InstanceID: core.StringPtr(""),
+		}
+	} else {
 		credentials = &watsonopenscalev2.WMLCredentialsCP4D{
 			Password: core.StringPtr(plan.Password.ValueString()),
 			Token:    core.StringPtr(plan.APIKey.ValueString()),
@@ -135,14 +140,6 @@ func (r *serviceProviderResource) Create(ctx context.Context, req resource.Creat
 		}
 	}
 
-	if !strings.Contains(plan.Url.ValueString(), "cloud.ibm.com") {
-		credentials = &watsonopenscalev2.WMLCredentialsCloud{
-			Apikey:     core.StringPtr(plan.APIKey.ValueString()),
-			URL:        core.StringPtr(plan.Url.ValueString()),
-			InstanceID: core.StringPtr(""),
-		}
-	}
-
 	if plan.AWSSecretAccessKey.ValueString() != "" {
 		credentials = &watsonopenscalev2.SageMakerCredentials{
 			AccessKeyID:     core.StringPtr(plan.AWSAccessKeyID.ValueString()),
@@ -159,12 +156,7 @@ func (r *serviceProviderResource) Create(ctx context.Context, req resource.Creat
 		DeploymentSpaceID:  core.StringPtr(plan.DeploymentSpaceID.ValueString()),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Creating Service Provider", "Could not create service provider, unexpected error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Create Service Provider", err.Error())
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Service Provider", "Could not create service provider", response, err) {
 		return
 	}
 
@@ -194,19 +186,26 @@ func (r *serviceProviderResource) Read(ctx context.Context, req resource.ReadReq
 	serviceProvider, response, err := wosClient.ServiceProvidersGet(&watsonopenscalev2.ServiceProvidersGetOptions{
 		ServiceProviderID: core.StringPtr(state.ID.ValueString()),
 	})
-	if utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		state.ID = types.StringValue(*serviceProvider.Metadata.ID)
-		state.Name = types.StringValue(*serviceProvider.Entity.Name)
-		state.ServiceType = types.StringValue(*serviceProvider.Entity.ServiceType)
-		state.OperationalSpaceID = types.StringValue(*serviceProvider.Entity.OperationalSpaceID)
-		diags = resp.State.Set(ctx, &state)
-	} else if response.StatusCode == 404 {
-		diags = resp.State.Set(ctx, &serviceProviderResourceModel{})
-	} else {
-		resp.Diagnostics.AddError("Error Getting Service Provider", "Could not read Service Provider ID "+state.ID.ValueString()+": "+err.Error())
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Service Provider", "Could not read Service Provider ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 
+	if serviceProvider.Metadata != nil && serviceProvider.Metadata.ID != nil {
+		state.ID = types.StringValue(*serviceProvider.Metadata.ID)
+	}
+	if serviceProvider.Entity != nil {
+		if serviceProvider.Entity.Name != nil {
+			state.Name = types.StringValue(*serviceProvider.Entity.Name)
+		}
+		if serviceProvider.Entity.ServiceType != nil {
+			state.ServiceType = types.StringValue(*serviceProvider.Entity.ServiceType)
+		}
+		if serviceProvider.Entity.OperationalSpaceID != nil {
+			state.OperationalSpaceID = types.StringValue(*serviceProvider.Entity.OperationalSpaceID)
+		}
+	}
+	diags = resp.State.Set(ctx, &state)
+
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,12 +234,7 @@ func (r *serviceProviderResource) Delete(ctx context.Context, req resource.Delet
 		ServiceProviderID: core.StringPtr(state.ID.ValueString()),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Service Provider", "Could not delete Service Provider ID "+state.ID.ValueString()+": "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Delete Service Provider", err.Error())
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Service Provider", "Could not delete Service Provider ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 }
@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DEFAULT_TIMEOUT_SPACE = 5 * time.Minute
+
+var (
+	_ resource.Resource                = &spaceResource{}
+	_ resource.ResourceWithConfigure   = &spaceResource{}
+	_ resource.ResourceWithImportState = &spaceResource{}
+)
+
+type spaceResource struct {
+	client *client.Client
+}
+
+type spaceResourceModel struct {
+	ID          types.String        `tfsdk:"id"`
+	Name        types.String        `tfsdk:"name"`
+	Description types.String        `tfsdk:"description"`
+	Tags        []types.String      `tfsdk:"tags"`
+	Stage       *spaceStageModel    `tfsdk:"stage"`
+	Storage     *spaceStorageModel  `tfsdk:"storage"`
+	Compute     []spaceComputeModel `tfsdk:"compute"`
+	Status      types.String        `tfsdk:"status"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type spaceStageModel struct {
+	Production types.Bool   `tfsdk:"production"`
+	Name       types.String `tfsdk:"name"`
+}
+
+type spaceStorageModel struct {
+	ResourceCrn types.String `tfsdk:"resource_crn"`
+	Delegated   types.Bool   `tfsdk:"delegated"`
+}
+
+type spaceComputeModel struct {
+	Name types.String `tfsdk:"name"`
+	Crn  types.String `tfsdk:"crn"`
+}
+
+func NewSpaceResource() resource.Resource {
+	return &spaceResource{}
+}
+
+func (r *spaceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *spaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (r *spaceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a deployment space on IBM Cloud Pak for Data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for space.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of space.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of space.",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "User-defined tags of space.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"stage": schema.SingleNestedAttribute{
+				Description: "Production flag and stage name of space.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"production": schema.BoolAttribute{
+						Description: "Whether the space is a production space. Cannot be modified after creation.",
+						Optional:    true,
+						PlanModifiers: []planmodifier.Bool{
+							// Null and false are equal, spaces are not production spaces by default.
+							boolplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+								resp.RequiresReplace = req.StateValue.ValueBool() != req.PlanValue.ValueBool()
+							}, "Replaces the space if production changes.", "Replaces the space if `production` changes."),
+						},
+					},
+					"name": schema.StringAttribute{
+						Description: "Stage name of space, e.g. development, test, production.",
+						Optional:    true,
+					},
+				},
+			},
+			"storage": schema.SingleNestedAttribute{
+				Description: "Cloud Object Storage instance of space. Required on IBM Cloud, not supported on IBM Cloud Pak for Data.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplaceIf(requiresReplaceIfStorageChanged, "Replaces the space if the Cloud Object Storage instance changes.", "Replaces the space if the Cloud Object Storage instance changes."),
+				},
+				Attributes: map[string]schema.Attribute{
+					"resource_crn": schema.StringAttribute{
+						Description: "CRN of Cloud Object Storage instance.",
+						Required:    true,
+					},
+					"delegated": schema.BoolAttribute{
+						Description: "Whether the Cloud Object Storage instance is delegated by the account admin. Not returned by the API, so it is taken from the configuration after import.",
+						Optional:    true,
+					},
+				},
+			},
+			"compute": schema.ListNestedAttribute{
+				Description: "Compute instances associated with space. Only supported on IBM Cloud.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of compute instance.",
+							Required:    true,
+						},
+						"crn": schema.StringAttribute{
+							Description: "CRN of compute instance.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of space.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *spaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SPACE)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	var stage *spacev2.StageRequest
+	if plan.Stage != nil {
+		stage = &spacev2.StageRequest{
+			Production: utils.If(!plan.Stage.Production.IsNull(), core.BoolPtr(plan.Stage.Production.ValueBool()), nil),
+			Name:       utils.If(plan.Stage.Name.ValueString() != "", core.StringPtr(plan.Stage.Name.ValueString()), nil),
+		}
+	}
+
+	var storage *spacev2.StorageRequest
+	if plan.Storage != nil {
+		storage = &spacev2.StorageRequest{
+			ResourceCrn: core.StringPtr(plan.Storage.ResourceCrn.ValueString()),
+			Delegated:   utils.If(!plan.Storage.Delegated.IsNull(), core.BoolPtr(plan.Storage.Delegated.ValueBool()), nil),
+		}
+	}
+
+	var compute []spacev2.ComputeRequest
+	for _, v := range plan.Compute {
+		compute = append(compute, spacev2.ComputeRequest{
+			Name: core.StringPtr(v.Name.ValueString()),
+			Crn:  core.StringPtr(v.Crn.ValueString()),
+		})
+	}
+
+	result, response, err := spaceClient.SpacesCreate(&spacev2.SpacesCreateOptions{
+		Name:        core.StringPtr(plan.Name.ValueString()),
+		Description: utils.If(plan.Description.ValueString() != "", core.StringPtr(plan.Description.ValueString()), nil),
+		Tags:        utils.If(len(plan.Tags) > 0, utils.ConvertString(plan.Tags), nil),
+		Stage:       stage,
+		Storage:     storage,
+		Compute:     compute,
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Space", "Could not create space", response, err) {
+		return
+	}
+
+	tflog.Info(ctx, "Created Space", map[string]interface{}{"space_id": result.Metadata.ID})
+
+	waiter := utils.StateWaiter[*spacev2.SpaceResource]{
+		Pending: []string{spacev2.SpaceStatus_State_Preparing},
+		Target:  []string{spacev2.SpaceStatus_State_Active},
+		Failed:  []string{spacev2.SpaceStatus_State_Error},
+		Refresh: func(ctx context.Context) (*spacev2.SpaceResource, string, error) {
+			space, response, err := spaceClient.SpacesGetWithContext(ctx, &spacev2.SpacesGetOptions{
+				SpaceID: result.Metadata.ID,
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			status := spaceStatusState(space)
+			if status == nil {
+				return nil, "", fmt.Errorf("space status missing in response")
+			}
+			tflog.Info(ctx, "Space Status", map[string]interface{}{"space_id": result.Metadata.ID, "status": *status})
+			return space, *status, nil
+		},
+		FailureMessage: func(space *spacev2.SpaceResource) string {
+			return spaceFailureMessage(space.Entity.Status.Failure)
+		},
+		Timeout: createTimeout,
+	}
+	space, err := waiter.Wait(ctx)
+	if !utils.CheckWait(&resp.Diagnostics, "Error Creating Space", "Space ID "+*result.Metadata.ID+" is not active", err) {
+		response, err := spaceClient.SpacesDelete(&spacev2.SpacesDeleteOptions{
+			SpaceID: result.Metadata.ID,
+		})
+		if err := utils.ResponseError(response, err); err != nil && !utils.IsNotFound(response) {
+			resp.Diagnostics.AddWarning("Unable to Delete Space", "Could not delete space ID "+*result.Metadata.ID+" after it failed to become active, delete it manually: "+err.Error())
+		}
+		return
+	}
+
+	plan.ID = types.StringValue(*result.Metadata.ID)
+	plan.Status = utils.StringValueOrNull(spaceStatusState(space))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	space, response, err := spaceClient.SpacesGet(&spacev2.SpacesGetOptions{
+		SpaceID: core.StringPtr(state.ID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Space", "Could not read Space ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	state.ID = types.StringValue(*space.Metadata.ID)
+	state.Name = types.StringValue(*space.Entity.Name)
+	state.Description = utils.StringValueOrNull(space.Entity.Description)
+	state.Status = utils.StringValueOrNull(spaceStatusState(space))
+
+	state.Tags = nil
+	for _, v := range space.Entity.Tags {
+		state.Tags = append(state.Tags, types.StringValue(v))
+	}
+
+	// The API always returns a stage, only add it to state if it was configured
+	// or differs from the defaults, e.g. after import.
+	if stage := space.Entity.Stage; stage != nil && (state.Stage != nil || (stage.Production != nil && *stage.Production) || (stage.Name != nil && *stage.Name != "")) {
+		if state.Stage == nil {
+			state.Stage = &spaceStageModel{Production: types.BoolNull()}
+		}
+		state.Stage.Name = utils.StringValueOrNull(stage.Name)
+		if stage.Production != nil && (!state.Stage.Production.IsNull() || *stage.Production) {
+			state.Stage.Production = types.BoolValue(*stage.Production)
+		}
+	}
+
+	if storage := space.Entity.Storage; storage != nil && storage.Properties != nil && storage.Properties.ResourceCrn != nil {
+		// delegated is not returned by the API, keep it from state.
+		delegated := types.BoolNull()
+		if state.Storage != nil {
+			delegated = state.Storage.Delegated
+		}
+		state.Storage = &spaceStorageModel{
+			ResourceCrn: types.StringValue(*storage.Properties.ResourceCrn),
+			Delegated:   delegated,
+		}
+	}
+
+	if state.Compute != nil || len(space.Entity.Compute) > 0 {
+		state.Compute = make([]spaceComputeModel, len(space.Entity.Compute))
+		for i, v := range space.Entity.Compute {
+			state.Compute[i] = spaceComputeModel{
+				Name: types.StringValue(*v.Name),
+				Crn:  types.StringValue(*v.Crn),
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var diags diag.Diagnostics
+
+	var state spaceResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan spaceResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var jsonPatches []spacev2.JSONPatchOperation
+
+	if plan.Name.ValueString() != state.Name.ValueString() {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/name"),
+			Value: core.StringPtr(plan.Name.ValueString()),
+		})
+	}
+
+	if plan.Description.ValueString() != state.Description.ValueString() {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/description"),
+			Value: core.StringPtr(plan.Description.ValueString()),
+		})
+	}
+
+	if !reflect.DeepEqual(utils.ConvertString(plan.Tags), utils.ConvertString(state.Tags)) {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/tags"),
+			Value: utils.ConvertString(plan.Tags),
+		})
+	}
+
+	var planStageName, stateStageName string
+	if plan.Stage != nil {
+		planStageName = plan.Stage.Name.ValueString()
+	}
+	if state.Stage != nil {
+		stateStageName = state.Stage.Name.ValueString()
+	}
+	if planStageName != stateStageName {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/stage/name"),
+			Value: core.StringPtr(planStageName),
+		})
+	}
+
+	computeChanged := len(plan.Compute) != len(state.Compute)
+	for i := 0; !computeChanged && i < len(plan.Compute); i++ {
+		computeChanged = !plan.Compute[i].Name.Equal(state.Compute[i].Name) || !plan.Compute[i].Crn.Equal(state.Compute[i].Crn)
+	}
+	if computeChanged {
+		compute := make([]spacev2.ComputeRequest, len(plan.Compute))
+		for i, v := range plan.Compute {
+			compute[i] = spacev2.ComputeRequest{
+				Name: core.StringPtr(v.Name.ValueString()),
+				Crn:  core.StringPtr(v.Crn.ValueString()),
+			}
+		}
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/compute"),
+			Value: compute,
+		})
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	plan.Status = state.Status
+
+	if len(jsonPatches) > 0 {
+		result, response, err := spaceClient.SpacesUpdate(&spacev2.SpacesUpdateOptions{
+			SpaceID:   core.StringPtr(state.ID.ValueString()),
+			JSONPatch: jsonPatches,
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Space", "Could not update space ID "+state.ID.ValueString(), response, err) {
+			return
+		}
+		if status := spaceStatusState(result); status != nil {
+			plan.Status = types.StringValue(*status)
+		}
+	}
+
+	plan.ID = state.ID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SPACE)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	response, err := spaceClient.SpacesDelete(&spacev2.SpacesDeleteOptions{
+		SpaceID: core.StringPtr(state.ID.ValueString()),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Space", "Could not delete space ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	// Spaces are deleted asynchronously, wait until the space is gone so dependent
+	// resources are not recreated against a space that is still being torn down.
+	waiter := utils.StateWaiter[*spacev2.SpaceResource]{
+		Pending: []string{spacev2.SpaceStatus_State_Active, spacev2.SpaceStatus_State_Deleting},
+		Target:  []string{spacev2.SpaceStatus_State_Deleted},
+		Failed:  []string{spacev2.SpaceStatus_State_Error},
+		Refresh: func(ctx context.Context) (*spacev2.SpaceResource, string, error) {
+			space, response, err := spaceClient.SpacesGetWithContext(ctx, &spacev2.SpacesGetOptions{
+				SpaceID: core.StringPtr(state.ID.ValueString()),
+			})
+			if utils.IsNotFound(response) {
+				return nil, spacev2.SpaceStatus_State_Deleted, nil
+			}
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			status := spaceStatusState(space)
+			if status == nil {
+				return nil, "", fmt.Errorf("space status missing in response")
+			}
+			return space, *status, nil
+		},
+		FailureMessage: func(space *spacev2.SpaceResource) string {
+			return spaceFailureMessage(space.Entity.Status.Failure)
+		},
+		Timeout: deleteTimeout,
+	}
+	_, err = waiter.Wait(ctx)
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Space", "Space ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+func (r *spaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// spaceStatusState returns the status of space, or nil if the response has no
+// status.
+func spaceStatusState(space *spacev2.SpaceResource) *string {
+	if space == nil || space.Entity == nil || space.Entity.Status == nil {
+		return nil
+	}
+	return space.Entity.Status.State
+}
+
+// requiresReplaceIfStorageChanged replaces a space if the Cloud Object Storage
+// instance is added, removed or changed. delegated is not returned by the API,
+// so it only counts if it is known in state.
+func requiresReplaceIfStorageChanged(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+	var state, plan *spaceStorageModel
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, req.Path, &state)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state == nil || plan == nil {
+		resp.RequiresReplace = (state == nil) != (plan == nil)
+		return
+	}
+	resp.RequiresReplace = !state.ResourceCrn.Equal(plan.ResourceCrn) || (!state.Delegated.IsNull() && !state.Delegated.Equal(plan.Delegated))
+}
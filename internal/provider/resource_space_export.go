@@ -0,0 +1,607 @@
+package provider
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DEFAULT_TIMEOUT_SPACE_EXPORT = 30 * time.Minute
+
+var (
+	_ resource.Resource              = &spaceExportResource{}
+	_ resource.ResourceWithConfigure = &spaceExportResource{}
+)
+
+type spaceExportResource struct {
+	client *client.Client
+}
+
+type spaceExportResourceModel struct {
+	ID            types.String        `tfsdk:"id"`
+	SpaceID       types.String        `tfsdk:"space_id"`
+	Name          types.String        `tfsdk:"name"`
+	Description   types.String        `tfsdk:"description"`
+	AllAssets     types.Bool          `tfsdk:"all_assets"`
+	AssetTypes    []types.String      `tfsdk:"asset_types"`
+	AssetIDs      []types.String      `tfsdk:"asset_ids"`
+	EncryptionKey types.String        `tfsdk:"encryption_key"`
+	OutputPath    types.String        `tfsdk:"output_path"`
+	Checksum      types.String        `tfsdk:"checksum"`
+	Status        types.String        `tfsdk:"status"`
+	Progress      types.Float64       `tfsdk:"progress"`
+	Failures      []spaceFailureModel `tfsdk:"failures"`
+	Assets        []spaceAssetModel   `tfsdk:"assets"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type spaceAssetModel struct {
+	AssetID   types.String `tfsdk:"asset_id"`
+	AssetType types.String `tfsdk:"asset_type"`
+	Name      types.String `tfsdk:"name"`
+	Status    types.String `tfsdk:"status"`
+	Message   types.String `tfsdk:"message"`
+}
+
+type spaceFailureModel struct {
+	Code       types.String   `tfsdk:"code"`
+	Message    types.String   `tfsdk:"message"`
+	Parameters []types.String `tfsdk:"parameters"`
+}
+
+func NewSpaceExportResource() resource.Resource {
+	return &spaceExportResource{}
+}
+
+func (r *spaceExportResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *spaceExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_export"
+}
+
+func (r *spaceExportResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports assets of a deployment space on IBM Cloud Pak for Data and downloads the archive to a local path.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for export.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID to export assets from.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of export.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of export.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"all_assets": schema.BoolAttribute{
+				Description: "Export all assets of space.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"asset_types": schema.ListAttribute{
+				Description: "Asset types to export, e.g. wml_model, wml_function, data_asset.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"asset_ids": schema.ListAttribute{
+				Description: "Asset IDs to export.",
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_key": schema.StringAttribute{
+				Description: "Encryption key used to encrypt sensitive data in the archive.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Local path to download the export archive (zip) to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Description: "SHA-256 checksum of the downloaded archive. A missing or modified archive is exported again.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					checksumOfOutputFile("output_path"),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of export.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"progress": schema.Float64Attribute{
+				Description: "Progress of export in percent.",
+				Computed:    true,
+			},
+			"failures": schema.ListNestedAttribute{
+				Description: "Errors reported for the export, including the assets that could not be exported.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Error code.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Error message.",
+							Computed:    true,
+						},
+						"parameters": schema.ListAttribute{
+							Description: "Error parameters, such as the affected asset.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"assets": schema.ListNestedAttribute{
+				Description: "Status of each asset of the export: exported for the assets in the archive and failed for the assets reported in failures.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asset_id": schema.StringAttribute{
+							Description: "Asset ID.",
+							Computed:    true,
+						},
+						"asset_type": schema.StringAttribute{
+							Description: "Asset type, e.g. wml_model.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Asset name.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Export status of the asset, exported or failed.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Error message of a failed asset.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *spaceExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SPACE_EXPORT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	result, response, err := spaceClient.ExportsStart(&spacev2.ExportsStartOptions{
+		SpaceID:     core.StringPtr(plan.SpaceID.ValueString()),
+		Name:        utils.If(plan.Name.ValueString() != "", core.StringPtr(plan.Name.ValueString()), nil),
+		Description: utils.If(plan.Description.ValueString() != "", core.StringPtr(plan.Description.ValueString()), nil),
+		Assets: &spacev2.ExportAssets{
+			AllAssets:  utils.If(!plan.AllAssets.IsNull(), core.BoolPtr(plan.AllAssets.ValueBool()), nil),
+			AssetTypes: utils.If(len(plan.AssetTypes) > 0, utils.ConvertString(plan.AssetTypes), nil),
+			AssetIds:   utils.If(len(plan.AssetIDs) > 0, utils.ConvertString(plan.AssetIDs), nil),
+		},
+		EncryptionKey: utils.If(plan.EncryptionKey.ValueString() != "", core.StringPtr(plan.EncryptionKey.ValueString()), nil),
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Exporting Space", "Could not start export of space ID "+plan.SpaceID.ValueString(), response, err) {
+		return
+	}
+
+	exportID := result.Metadata.ID
+	tflog.Info(ctx, "Started Space Export", map[string]interface{}{"export_id": exportID})
+
+	waiter := utils.StateWaiter[*spacev2.ExportResource]{
+		Pending: []string{spacev2.ImportExportStatus_State_Pending, spacev2.ImportExportStatus_State_Running},
+		Target:  []string{spacev2.ImportExportStatus_State_Completed},
+		Failed:  []string{spacev2.ImportExportStatus_State_Failed, spacev2.ImportExportStatus_State_Canceled},
+		Refresh: func(ctx context.Context) (*spacev2.ExportResource, string, error) {
+			export, response, err := spaceClient.ExportsGetWithContext(ctx, &spacev2.ExportsGetOptions{
+				ExportID: exportID,
+				SpaceID:  core.StringPtr(plan.SpaceID.ValueString()),
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			tflog.Info(ctx, "Space Export Status", map[string]interface{}{"export_id": exportID, "status": *export.Entity.Status.State})
+			return export, *export.Entity.Status.State, nil
+		},
+		FailureMessage: func(export *spacev2.ExportResource) string {
+			return spaceFailureMessage(export.Entity.Status.Failure)
+		},
+		Timeout: createTimeout,
+	}
+	export, err := waiter.Wait(ctx)
+
+	plan.ID = types.StringValue(*exportID)
+	if export != nil {
+		setSpaceExportState(&plan, export)
+	}
+
+	if !utils.CheckWait(&resp.Diagnostics, "Error Exporting Space", "Export ID "+*exportID+" is not completed", err) {
+		deleteUntrackedSpaceExport(&resp.Diagnostics, spaceClient, plan.SpaceID.ValueString(), *exportID)
+		return
+	}
+
+	checksum, err := downloadSpaceExport(spaceClient, plan.SpaceID.ValueString(), *exportID, plan.OutputPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Downloading Space Export", "Could not download export ID "+*exportID+": "+err.Error())
+		deleteUntrackedSpaceExport(&resp.Diagnostics, spaceClient, plan.SpaceID.ValueString(), *exportID)
+		return
+	}
+	plan.Checksum = types.StringValue(checksum)
+
+	assets, err := exportArchiveAssets(plan.OutputPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Read Export Archive", "Could not list the assets of "+plan.OutputPath.ValueString()+": "+err.Error())
+	}
+	var failure *spacev2.Error
+	if export != nil && export.Entity != nil && export.Entity.Status != nil {
+		failure = export.Entity.Status.Failure
+	}
+	plan.Assets = spaceAssetModels(assets, failure, "exported")
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	export, response, err := spaceClient.ExportsGet(&spacev2.ExportsGetOptions{
+		ExportID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Space Export", "Could not read export ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	// A missing or modified archive is planned for replacement by the checksum
+	// plan modifier.
+	setSpaceExportState(&state, export)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state spaceExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan spaceExportResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All other attributes require replacement, so only timeouts can change.
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SPACE_EXPORT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	response, err := spaceClient.ExportsCancel(&spacev2.ExportsCancelOptions{
+		ExportID:   core.StringPtr(state.ID.ValueString()),
+		SpaceID:    core.StringPtr(state.SpaceID.ValueString()),
+		HardDelete: core.BoolPtr(true),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Space Export", "Could not delete export ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	err = utils.WaitForDeletion(ctx, deleteTimeout, func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := spaceClient.ExportsGetWithContext(ctx, &spacev2.ExportsGetOptions{
+			ExportID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+		})
+		return response, err
+	})
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Space Export", "Export ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+func setSpaceExportState(state *spaceExportResourceModel, export *spacev2.ExportResource) {
+	if export == nil || export.Entity == nil || export.Entity.Status == nil {
+		return
+	}
+	state.Status = utils.StringValueOrNull(export.Entity.Status.State)
+	state.Progress = types.Float64Null()
+	if export.Entity.Status.Progress != nil {
+		state.Progress = types.Float64Value(*export.Entity.Status.Progress)
+	}
+	state.Failures = spaceFailureModels(export.Entity.Status.Failure)
+}
+
+// deleteUntrackedSpaceExport deletes an export that failed during Create and is
+// therefore not tracked in state, rather than leaving it behind.
+func deleteUntrackedSpaceExport(diags *diag.Diagnostics, spaceClient *spacev2.SpaceV2, spaceID string, exportID string) {
+	response, err := spaceClient.ExportsCancel(&spacev2.ExportsCancelOptions{
+		ExportID:   core.StringPtr(exportID),
+		SpaceID:    core.StringPtr(spaceID),
+		HardDelete: core.BoolPtr(true),
+	})
+	if err := utils.ResponseError(response, err); err != nil && !utils.IsNotFound(response) {
+		diags.AddWarning("Unable to Delete Space Export", "Could not delete export ID "+exportID+" after it failed, delete it manually: "+err.Error())
+	}
+}
+
+// downloadSpaceExport downloads the archive of an export to outputPath and
+// returns its checksum. A partially written archive is removed.
+func downloadSpaceExport(spaceClient *spacev2.SpaceV2, spaceID string, exportID string, outputPath string) (string, error) {
+	content, response, err := spaceClient.ExportsDownload(&spacev2.ExportsDownloadOptions{
+		ExportID: core.StringPtr(exportID),
+		SpaceID:  core.StringPtr(spaceID),
+	})
+	if err := utils.ResponseError(response, err); err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(outputPath)
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// exportArchiveAsset is the metadata of an asset in an export archive, which
+// stores every asset as a JSON document with the usual asset metadata.
+type exportArchiveAsset struct {
+	Metadata struct {
+		AssetID   string `json:"asset_id"`
+		AssetType string `json:"asset_type"`
+		Name      string `json:"name"`
+	} `json:"metadata"`
+}
+
+// exportArchiveAssets lists the assets in the export archive at path.
+func exportArchiveAssets(path string) ([]exportArchiveAsset, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	var assets []exportArchiveAsset
+	seen := map[string]bool{}
+	for _, f := range archive.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+		reader, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		var asset exportArchiveAsset
+		err = json.NewDecoder(reader).Decode(&asset)
+		reader.Close()
+		if err != nil || asset.Metadata.AssetID == "" || seen[asset.Metadata.AssetID] {
+			continue
+		}
+		seen[asset.Metadata.AssetID] = true
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
+// spaceAssetModels returns the status of the assets in an export archive and
+// of the assets reported in failure. Assets in the archive get status unless
+// failure reports them, in which case their status is failed.
+func spaceAssetModels(assets []exportArchiveAsset, failure *spacev2.Error, status string) []spaceAssetModel {
+	failed := map[string]*string{}
+	var failedIDs []string
+	if failure != nil {
+		for _, v := range failure.Errors {
+			if len(v.Parameters) == 0 {
+				continue
+			}
+			if _, ok := failed[v.Parameters[0]]; !ok {
+				failedIDs = append(failedIDs, v.Parameters[0])
+			}
+			failed[v.Parameters[0]] = v.Message
+		}
+	}
+
+	models := []spaceAssetModel{}
+	for _, v := range assets {
+		model := spaceAssetModel{
+			AssetID:   types.StringValue(v.Metadata.AssetID),
+			AssetType: utils.If(v.Metadata.AssetType != "", types.StringValue(v.Metadata.AssetType), types.StringNull()),
+			Name:      utils.If(v.Metadata.Name != "", types.StringValue(v.Metadata.Name), types.StringNull()),
+			Status:    types.StringValue(status),
+			Message:   types.StringNull(),
+		}
+		if message, ok := failed[v.Metadata.AssetID]; ok {
+			model.Status = types.StringValue("failed")
+			model.Message = utils.StringValueOrNull(message)
+			delete(failed, v.Metadata.AssetID)
+		}
+		models = append(models, model)
+	}
+	for _, id := range failedIDs {
+		message, ok := failed[id]
+		if !ok {
+			continue
+		}
+		models = append(models, spaceAssetModel{
+			AssetID:   types.StringValue(id),
+			AssetType: types.StringNull(),
+			Name:      types.StringNull(),
+			Status:    types.StringValue("failed"),
+			Message:   utils.StringValueOrNull(message),
+		})
+	}
+	return models
+}
+
+func spaceFailureModels(failure *spacev2.Error) []spaceFailureModel {
+	if failure == nil {
+		return nil
+	}
+	failures := make([]spaceFailureModel, len(failure.Errors))
+	for i, v := range failure.Errors {
+		failures[i] = spaceFailureModel{
+			Code:    utils.StringValueOrNull(v.Code),
+			Message: utils.StringValueOrNull(v.Message),
+		}
+		for _, p := range v.Parameters {
+			failures[i].Parameters = append(failures[i].Parameters, types.StringValue(p))
+		}
+	}
+	return failures
+}
+
+func spaceFailureMessage(failure *spacev2.Error) string {
+	if failure == nil {
+		return ""
+	}
+	var message string
+	for _, v := range failure.Errors {
+		if v.Message != nil {
+			message += *v.Message + " "
+		}
+	}
+	return strings.TrimSpace(message)
+}
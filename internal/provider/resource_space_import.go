@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DEFAULT_TIMEOUT_SPACE_IMPORT = 30 * time.Minute
+
+var (
+	_ resource.Resource              = &spaceImportResource{}
+	_ resource.ResourceWithConfigure = &spaceImportResource{}
+)
+
+type spaceImportResource struct {
+	client *client.Client
+}
+
+type spaceImportResourceModel struct {
+	ID            types.String        `tfsdk:"id"`
+	SpaceID       types.String        `tfsdk:"space_id"`
+	ArchivePath   types.String        `tfsdk:"archive_path"`
+	Checksum      types.String        `tfsdk:"checksum"`
+	EncryptionKey types.String        `tfsdk:"encryption_key"`
+	Status        types.String        `tfsdk:"status"`
+	Progress      types.Float64       `tfsdk:"progress"`
+	Failures      []spaceFailureModel `tfsdk:"failures"`
+	Assets        []spaceAssetModel   `tfsdk:"assets"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewSpaceImportResource() resource.Resource {
+	return &spaceImportResource{}
+}
+
+func (r *spaceImportResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *spaceImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_import"
+}
+
+func (r *spaceImportResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports an export archive into a deployment space on IBM Cloud Pak for Data. Destroying this resource does not delete the imported assets.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for import.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID to import assets into.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"archive_path": schema.StringAttribute{
+				Description: "Local path of the export archive (zip) to import.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"checksum": schema.StringAttribute{
+				Description: "SHA-256 checksum of the archive. Computed when not set, changing it imports the archive again.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_key": schema.StringAttribute{
+				Description: "Encryption key used to decrypt sensitive data in the archive.",
+				Optional:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of import.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"progress": schema.Float64Attribute{
+				Description: "Progress of import in percent.",
+				Computed:    true,
+			},
+			"failures": schema.ListNestedAttribute{
+				Description: "Errors reported for the import, including the assets that could not be imported.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"code": schema.StringAttribute{
+							Description: "Error code.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Error message.",
+							Computed:    true,
+						},
+						"parameters": schema.ListAttribute{
+							Description: "Error parameters, such as the affected asset.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"assets": schema.ListNestedAttribute{
+				Description: "Status of each asset of the import: imported for the assets in the archive and failed for the assets reported in failures.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asset_id": schema.StringAttribute{
+							Description: "Asset ID.",
+							Computed:    true,
+						},
+						"asset_type": schema.StringAttribute{
+							Description: "Asset type, e.g. wml_model.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Asset name.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Import status of the asset, imported or failed.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Error message of a failed asset.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *spaceImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceImportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SPACE_IMPORT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksum, err := utils.FileChecksum(plan.ArchivePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read import archive", err.Error())
+		return
+	}
+	if !plan.Checksum.IsUnknown() && plan.Checksum.ValueString() != checksum {
+		resp.Diagnostics.AddAttributeError(path.Root("checksum"), "Checksum Mismatch", "Checksum of "+plan.ArchivePath.ValueString()+" is "+checksum+", expected "+plan.Checksum.ValueString()+".")
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	file, err := os.Open(plan.ArchivePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to open import archive", err.Error())
+		return
+	}
+	defer file.Close()
+
+	result, response, err := spaceClient.ImportsStart(&spacev2.ImportsStartOptions{
+		SpaceID:         core.StringPtr(plan.SpaceID.ValueString()),
+		File:            file,
+		FileContentType: core.StringPtr("application/zip"),
+		EncryptionKey:   utils.If(plan.EncryptionKey.ValueString() != "", core.StringPtr(plan.EncryptionKey.ValueString()), nil),
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Importing Space", "Could not start import into space ID "+plan.SpaceID.ValueString(), response, err) {
+		return
+	}
+
+	importID := result.Metadata.ID
+	tflog.Info(ctx, "Started Space Import", map[string]interface{}{"import_id": importID})
+
+	waiter := utils.StateWaiter[*spacev2.ImportResource]{
+		Pending: []string{spacev2.ImportExportStatus_State_Pending, spacev2.ImportExportStatus_State_Running},
+		Target:  []string{spacev2.ImportExportStatus_State_Completed},
+		Failed:  []string{spacev2.ImportExportStatus_State_Failed, spacev2.ImportExportStatus_State_Canceled},
+		Refresh: func(ctx context.Context) (*spacev2.ImportResource, string, error) {
+			spaceImport, response, err := spaceClient.ImportsGetWithContext(ctx, &spacev2.ImportsGetOptions{
+				ImportID: importID,
+				SpaceID:  core.StringPtr(plan.SpaceID.ValueString()),
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			tflog.Info(ctx, "Space Import Status", map[string]interface{}{"import_id": importID, "status": *spaceImport.Entity.Status.State})
+			return spaceImport, *spaceImport.Entity.Status.State, nil
+		},
+		FailureMessage: func(spaceImport *spacev2.ImportResource) string {
+			return spaceFailureMessage(spaceImport.Entity.Status.Failure)
+		},
+		Timeout: createTimeout,
+	}
+	spaceImport, err := waiter.Wait(ctx)
+	if !utils.CheckWait(&resp.Diagnostics, "Error Importing Space", "Import ID "+*importID+" is not completed", err) {
+		return
+	}
+
+	plan.ID = types.StringValue(*importID)
+	plan.Checksum = types.StringValue(checksum)
+	setSpaceImportState(&plan, spaceImport)
+
+	assets, err := exportArchiveAssets(plan.ArchivePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to Read Import Archive", "Could not list the assets of "+plan.ArchivePath.ValueString()+": "+err.Error())
+	}
+	plan.Assets = spaceAssetModels(assets, spaceImport.Entity.Status.Failure, "imported")
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	spaceImport, response, err := spaceClient.ImportsGet(&spacev2.ImportsGetOptions{
+		ImportID: core.StringPtr(state.ID.ValueString()),
+		SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Space Import", "Could not read import ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	setSpaceImportState(&state, spaceImport)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state spaceImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan spaceImportResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// All other attributes require replacement, so only timeouts can change.
+	state.Timeouts = plan.Timeouts
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceImportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SPACE_IMPORT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	response, err := spaceClient.ImportsCancel(&spacev2.ImportsCancelOptions{
+		ImportID:   core.StringPtr(state.ID.ValueString()),
+		SpaceID:    core.StringPtr(state.SpaceID.ValueString()),
+		HardDelete: core.BoolPtr(true),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Space Import", "Could not delete import ID "+state.ID.ValueString(), response, err) {
+		return
+	}
+
+	err = utils.WaitForDeletion(ctx, deleteTimeout, func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := spaceClient.ImportsGetWithContext(ctx, &spacev2.ImportsGetOptions{
+			ImportID: core.StringPtr(state.ID.ValueString()),
+			SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+		})
+		return response, err
+	})
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Space Import", "Import ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+func setSpaceImportState(state *spaceImportResourceModel, spaceImport *spacev2.ImportResource) {
+	if spaceImport == nil || spaceImport.Entity == nil || spaceImport.Entity.Status == nil {
+		return
+	}
+	state.Status = utils.StringValueOrNull(spaceImport.Entity.Status.State)
+	state.Progress = types.Float64Null()
+	if spaceImport.Entity.Status.Progress != nil {
+		state.Progress = types.Float64Value(*spaceImport.Entity.Status.Progress)
+	}
+	state.Failures = spaceFailureModels(spaceImport.Entity.Status.Failure)
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-ibmcpd/internal/go-sdk/client"
+	"terraform-provider-ibmcpd/internal/go-sdk/spacev2"
+	"terraform-provider-ibmcpd/internal/utils"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &spaceMemberResource{}
+	_ resource.ResourceWithConfigure   = &spaceMemberResource{}
+	_ resource.ResourceWithImportState = &spaceMemberResource{}
+)
+
+type spaceMemberResource struct {
+	client *client.Client
+}
+
+type spaceMemberResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	SpaceID  types.String `tfsdk:"space_id"`
+	MemberID types.String `tfsdk:"member_id"`
+	Type     types.String `tfsdk:"type"`
+	Role     types.String `tfsdk:"role"`
+	State    types.String `tfsdk:"state"`
+}
+
+func NewSpaceMemberResource() resource.Resource {
+	return &spaceMemberResource{}
+}
+
+func (r *spaceMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*client.Client)
+}
+
+func (r *spaceMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_member"
+}
+
+func (r *spaceMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a member of a deployment space on IBM Cloud Pak for Data.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier for space member, in the format `<space_id>/<member_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				Description: "Space ID of member.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"member_id": schema.StringAttribute{
+				Description: "IAM ID of user, service ID or access group of member.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of member. One of `user`, `service` or `group`. Defaults to `user`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(spacev2.MemberResource_Type_User, spacev2.MemberResource_Type_Service, spacev2.MemberResource_Type_Group),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "Role of member. One of `viewer`, `editor` or `admin`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(spacev2.MemberResource_Role_Viewer, spacev2.MemberResource_Role_Editor, spacev2.MemberResource_Role_Admin),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description: "State of member. One of `active` or `pending`. Only supported for members of type `user`.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(spacev2.MemberResource_State_Active, spacev2.MemberResource_State_Pending),
+				},
+			},
+		},
+	}
+}
+
+func (r *spaceMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	memberType := utils.If(plan.Type.ValueString() != "", plan.Type.ValueString(), spacev2.MemberResource_Type_User)
+
+	result, response, err := spaceClient.MembersCreate(&spacev2.MembersCreateOptions{
+		SpaceID: core.StringPtr(plan.SpaceID.ValueString()),
+		Members: []spacev2.MemberResource{
+			{
+				ID:    core.StringPtr(plan.MemberID.ValueString()),
+				Type:  core.StringPtr(memberType),
+				Role:  core.StringPtr(plan.Role.ValueString()),
+				State: utils.If(plan.State.ValueString() != "", core.StringPtr(plan.State.ValueString()), nil),
+			},
+		},
+	})
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Space Member", "Could not add member "+plan.MemberID.ValueString()+" to space ID "+plan.SpaceID.ValueString(), response, err) {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.SpaceID.ValueString() + "/" + plan.MemberID.ValueString())
+	plan.Type = types.StringValue(memberType)
+	// Keep the planned state unless the response reports the member's state.
+	if plan.State.IsUnknown() {
+		plan.State = types.StringNull()
+	}
+	for _, v := range result.Resources {
+		if v.ID != nil && *v.ID == plan.MemberID.ValueString() && v.State != nil {
+			plan.State = types.StringValue(*v.State)
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	member, response, err := spaceClient.MembersGet(&spacev2.MembersGetOptions{
+		SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+		MemberID: core.StringPtr(state.MemberID.ValueString()),
+	})
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Space Member", "Could not read member "+state.MemberID.ValueString()+" of space ID "+state.SpaceID.ValueString(), response, err) {
+		return
+	}
+
+	state.ID = types.StringValue(state.SpaceID.ValueString() + "/" + state.MemberID.ValueString())
+	state.Role = utils.StringValueOrNull(member.Role)
+	state.State = utils.StringValueOrNull(member.State)
+	if member.Type != nil {
+		state.Type = types.StringValue(*member.Type)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var diags diag.Diagnostics
+
+	var state spaceMemberResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan spaceMemberResourceModel
+	diags = req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var jsonPatches []spacev2.JSONPatchOperation
+	if plan.Role.ValueString() != state.Role.ValueString() {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/role"),
+			Value: core.StringPtr(plan.Role.ValueString()),
+		})
+	}
+	if plan.State.ValueString() != "" && plan.State.ValueString() != state.State.ValueString() {
+		jsonPatches = append(jsonPatches, spacev2.JSONPatchOperation{
+			Op:    core.StringPtr(spacev2.JSONPatchOperation_Op_Replace),
+			Path:  core.StringPtr("/state"),
+			Value: core.StringPtr(plan.State.ValueString()),
+		})
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	if len(jsonPatches) > 0 {
+		member, response, err := spaceClient.MembersUpdate(&spacev2.MembersUpdateOptions{
+			SpaceID:   core.StringPtr(state.SpaceID.ValueString()),
+			MemberID:  core.StringPtr(state.MemberID.ValueString()),
+			JSONPatch: jsonPatches,
+		})
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Space Member", "Could not update member "+state.MemberID.ValueString()+" of space ID "+state.SpaceID.ValueString(), response, err) {
+			return
+		}
+		plan.State = utils.StringValueOrNull(member.State)
+	}
+
+	plan.ID = state.ID
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spaceClient, err := r.client.SpaceClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get Space Client", err.Error())
+		return
+	}
+
+	response, err := spaceClient.MembersDelete(&spacev2.MembersDeleteOptions{
+		SpaceID:  core.StringPtr(state.SpaceID.ValueString()),
+		MemberID: core.StringPtr(state.MemberID.ValueString()),
+	})
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Space Member", "Could not delete member "+state.MemberID.ValueString()+" of space ID "+state.SpaceID.ValueString(), response, err) {
+		return
+	}
+}
+
+func (r *spaceMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	idParts := strings.SplitN(req.ID, "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", "Expected import identifier with format <space_id>/<member_id>. Got: "+req.ID)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("space_id"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_id"), idParts[1])...)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sagemakerruntime"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -30,8 +31,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const NUM_TRIES_SUBSCRIPTION = 30
-const TIMEOUT_SUBSCRIPTION = 5 * time.Second
+const DEFAULT_TIMEOUT_SUBSCRIPTION = 10 * time.Minute
 
 var (
 	_ resource.Resource                = &subscriptionResource{}
@@ -57,11 +57,15 @@ type subscriptionResourceModel struct {
 	TrainingDataReference *subscriptionTrainingDataReferenceModel `tfsdk:"training_data_reference"`
 	TrainingDataSchema    []sparkStructFieldModel                 `tfsdk:"training_data_schema"`
 
-	PayloadFile types.String `tfsdk:"payload_file"`
+	PayloadFile      types.String `tfsdk:"payload_file"`
+	PayloadChecksum  types.String `tfsdk:"payload_checksum"`
+	CleanupLocalFile types.Bool   `tfsdk:"cleanup_local_file"`
 
 	AWSAccessKeyID     types.String `tfsdk:"aws_access_key_id"`
 	AWSSecretAccessKey types.String `tfsdk:"aws_secret_access_key"`
 	AWSRegion          types.String `tfsdk:"aws_region"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type sparkStructFieldModel struct {
@@ -138,7 +142,7 @@ func (r *subscriptionResource) Metadata(_ context.Context, req resource.Metadata
 // 	}
 // }
 
-func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *subscriptionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -152,12 +156,21 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 			},
 			"data_mart_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"service_provider_id": schema.StringAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"asset": schema.SingleNestedAttribute{
 				Required: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"asset_id": schema.StringAttribute{
 						Required: true,
@@ -181,15 +194,27 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 				Attributes: map[string]schema.Attribute{
 					"deployment_id": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"deployment_type": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"deployment_url": schema.StringAttribute{
 						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"scoring_url": schema.StringAttribute{
 						Optional: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"name": schema.StringAttribute{
 						Optional: true,
@@ -293,6 +318,17 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 			"payload_file": schema.StringAttribute{
 				Optional: true,
 			},
+			"payload_checksum": schema.StringAttribute{
+				Description: "SHA-256 checksum of payload_file. A change scores and stores the payload again.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					checksumFromFile("payload_file"),
+				},
+			},
+			"cleanup_local_file": schema.BoolAttribute{
+				Description: "Delete payload_file after the payload was stored.",
+				Optional:    true,
+			},
 			"aws_access_key_id": schema.StringAttribute{
 				Optional: true,
 			},
@@ -304,6 +340,13 @@ func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaReques
 				Optional: true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -315,6 +358,12 @@ func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, DEFAULT_TIMEOUT_SUBSCRIPTION)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
@@ -400,241 +449,71 @@ func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRe
 			},
 		},
 	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Creating Subscription", "Could not create subscription, unexpected error: "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Create Subscription", err.Error())
+	if !utils.CheckResponse(&resp.Diagnostics, "Error Creating Subscription", "Could not create subscription", response, err) {
 		return
 	}
 
 	tflog.Info(ctx, "Created Subscription", map[string]interface{}{"subscription_id": result.Metadata.ID})
 
-	var subscriptionStatus string
-	for i := 1; i < NUM_TRIES_SUBSCRIPTION; i++ {
-		time.Sleep(TIMEOUT_SUBSCRIPTION)
-		resultSubscription, response, err := wosClient.SubscriptionsGet(&watsonopenscalev2.SubscriptionsGetOptions{
-			SubscriptionID: result.Metadata.ID,
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Getting Subscription", "Could not get subscription, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Get Subscription", err.Error())
-			return
-		}
-		subscriptionStatus = *resultSubscription.Entity.Status.State
-		tflog.Info(ctx, "Subscription Status", map[string]interface{}{"subscription_id": result.Metadata.ID, "status": subscriptionStatus})
-		if subscriptionStatus == "active" {
-			break
-		}
+	subscriptionWaiter := utils.StateWaiter[*watsonopenscalev2.SubscriptionResponse]{
+		Pending: []string{watsonopenscalev2.Status_State_Preparing},
+		Target:  []string{watsonopenscalev2.Status_State_Active},
+		Failed:  []string{watsonopenscalev2.Status_State_Error},
+		Refresh: func(ctx context.Context) (*watsonopenscalev2.SubscriptionResponse, string, error) {
+			subscription, response, err := wosClient.SubscriptionsGetWithContext(ctx, &watsonopenscalev2.SubscriptionsGetOptions{
+				SubscriptionID: result.Metadata.ID,
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			if subscription.Entity == nil || subscription.Entity.Status == nil || subscription.Entity.Status.State == nil {
+				return nil, "", fmt.Errorf("subscription status missing in response")
+			}
+			tflog.Info(ctx, "Subscription Status", map[string]interface{}{"subscription_id": result.Metadata.ID, "status": *subscription.Entity.Status.State})
+			return subscription, *subscription.Entity.Status.State, nil
+		},
+		FailureMessage: func(subscription *watsonopenscalev2.SubscriptionResponse) string {
+			return openScaleFailureMessage(subscription.Entity.Status.Failure)
+		},
+		Timeout: createTimeout,
 	}
-
-	if subscriptionStatus != "active" {
+	_, err = subscriptionWaiter.Wait(ctx)
+	if !utils.CheckWait(&resp.Diagnostics, "Error Creating Subscription", "Subscription ID "+*result.Metadata.ID+" is not active", err) {
 		wosClient.SubscriptionsDelete(&watsonopenscalev2.SubscriptionsDeleteOptions{
 			SubscriptionID: result.Metadata.ID,
 		})
-		resp.Diagnostics.AddError("Error Creating Subscription", "Subscription ID "+*result.Metadata.ID+" status is not active. Status is "+subscriptionStatus)
 		return
 	}
 
-	var dataSetID *string
-	var numPayloadRecords int
-	for i := 1; i < NUM_TRIES_SUBSCRIPTION; i++ {
-		time.Sleep(TIMEOUT_SUBSCRIPTION)
-		resultDataSets, response, err := wosClient.DataSetsList(&watsonopenscalev2.DataSetsListOptions{
-			TargetTargetID:   result.Metadata.ID,
-			Type:             core.StringPtr("payload_logging"),
-			TargetTargetType: core.StringPtr("subscription"),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Listing Datasets", "Could not list datasets, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to List Datasets", err.Error())
-			return
-		}
-		if len(resultDataSets.DataSets) > 0 {
-			dataSetID = resultDataSets.DataSets[0].Metadata.ID
-			tflog.Info(ctx, "Created Payload Dataset", map[string]interface{}{"dataset_id": dataSetID})
-			break
-		}
-	}
-	if *dataSetID == "" {
+	dataSet, err := waitForDataSet(ctx, wosClient, *result.Metadata.ID, "payload_logging", createTimeout)
+	if !utils.CheckWait(&resp.Diagnostics, "Error Creating Payload Dataset", "Payload dataset of Subscription ID "+*result.Metadata.ID+" is not active", err) {
 		wosClient.SubscriptionsDelete(&watsonopenscalev2.SubscriptionsDeleteOptions{
 			SubscriptionID: result.Metadata.ID,
 		})
-		resp.Diagnostics.AddError("Error Creating Payload Dataset", "Unable to create payload dataset for Subscription ID "+*result.Metadata.ID+".")
-		return
-	}
-
-	serviceProvider, response, err := wosClient.ServiceProvidersGet(&watsonopenscalev2.ServiceProvidersGetOptions{
-		ServiceProviderID: core.StringPtr(plan.ServiceProviderID.ValueString()),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Getting Service Provider", "Could not get service provider, unexpected error: "+err.Error())
 		return
 	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Get Service Provider", err.Error())
-		return
-	}
-
-	var jsonPayload map[string][]interface{}
-	var scoringFields []string
-	var payloadContent []byte
+	dataSetID := dataSet.Metadata.ID
+	tflog.Info(ctx, "Created Payload Dataset", map[string]interface{}{"dataset_id": dataSetID})
 
-	if plan.PayloadFile.ValueString() != "" {
-		file, err := os.Open(plan.PayloadFile.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to open payload file", err.Error())
-			return
-		}
-		defer file.Close()
-		payloadContent, err = io.ReadAll(file)
+	if plan.PayloadFile.ValueString() == "" {
+		plan.PayloadChecksum = types.StringNull()
+	} else {
+		checksum, _, err := plannedFileChecksum(plan.PayloadFile.ValueString(), plan.PayloadChecksum, types.StringNull())
 		if err != nil {
 			resp.Diagnostics.AddError("Unable to read payload file", err.Error())
 			return
 		}
-		json.Unmarshal(payloadContent, &jsonPayload)
-		numPayloadRecords = len(jsonPayload["values"])
-		scoringFields = make([]string, len(jsonPayload["fields"]))
-		for i, v := range jsonPayload["fields"] {
-			scoringFields[i] = v.(string)
-		}
-	}
-
-	if *serviceProvider.Entity.ServiceType == "amazon_sagemaker" {
-		scoringValues := make([][]interface{}, len(jsonPayload["values"]))
-		for i, v := range jsonPayload["values"] {
-			scoringValues[i] = v.([]interface{})
-		}
-
-		sess, err := session.NewSession(&aws.Config{
-			Credentials: credentials.NewStaticCredentials(plan.AWSAccessKeyID.ValueString(), plan.AWSSecretAccessKey.ValueString(), ""),
-			Region:      aws.String(plan.AWSRegion.ValueString())},
-		)
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to create AWS session", err.Error())
-			return
-		}
-
-		scoringPayload := fmt.Sprintf(`{"input_data": [%s]}`, string(payloadContent))
-		svc := sagemakerruntime.New(sess)
-		response, err := svc.InvokeEndpoint(&sagemakerruntime.InvokeEndpointInput{
-			EndpointName: aws.String("pipeline-endpoint"),
-			ContentType:  aws.String("application/json"),
-			Body:         []byte(scoringPayload),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to score AWS endpoint", err.Error())
-			return
-		}
-
-		var jsonResponse map[string][]interface{}
-		err = json.Unmarshal(response.Body, &jsonResponse)
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to parse AWS endpoint response", err.Error())
-			return
-		}
-
-		values := []string{}
-		for _, v := range jsonResponse["predictions"] {
-			val := v.(map[string]interface{})
-			values = append(values, fmt.Sprintf(`[%f, "%s"]`, val["score"], val["predicted_label"]))
-		}
-
-		var jsonValues []interface{}
-		err = json.Unmarshal([]byte(fmt.Sprintf("[%s]", strings.Join(values, ","))), &jsonValues)
-		if err != nil {
-			panic(err)
-		}
-
-		_, resultResponse, err := wosClient.RecordsAdd(&watsonopenscalev2.RecordsAddOptions{
-			DataSetID: dataSetID,
-			DatasetRecordsPayloadItem: []watsonopenscalev2.DatasetRecordsPayloadItemIntf{
-				&watsonopenscalev2.DatasetRecordsPayloadItem{
-					Request: &watsonopenscalev2.ScoringPayloadRequestRequest{
-						Fields: scoringFields,
-						Values: scoringValues,
-					},
-					Response: &watsonopenscalev2.ScoringPayloadRequestResponse{
-						Fields: []string{"score", "predicted_label"},
-						Values: jsonValues,
-					},
-				},
-			},
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Storing Payload", "Could not store payload, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, resultResponse.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Store Payload", err.Error())
-			return
-		}
-	}
-
-	if *serviceProvider.Entity.ServiceType == "watson_machine_learning" && plan.PayloadFile.ValueString() != "" {
-		wmlClient, err := r.client.WMLClient(context.Background())
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to get WML Client", err.Error())
-			return
-		}
-
-		objValues, err := json.Marshal(jsonPayload["values"])
-		if err != nil {
-			resp.Diagnostics.AddError("Unable to parse payload file", err.Error())
-			return
-		}
-		var scoringValues [][]interface{}
-		json.Unmarshal(objValues, &scoringValues)
-
-		_, response, err = wmlClient.DeploymentsComputePredictions(&watsonmachinelearningv4.DeploymentsComputePredictionsOptions{
-			DeploymentID: core.StringPtr(plan.Deployment.DeploymentID.ValueString()),
-			InputData: []watsonmachinelearningv4.InputDataArray{
-				{Fields: scoringFields, Values: scoringValues},
-			},
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Scoring Payload", "Could not score payload, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to Score Payload", err.Error())
-			return
-		}
+		plan.PayloadChecksum = types.StringValue(checksum)
 	}
 
-	for i := 1; i < NUM_TRIES_SUBSCRIPTION; i++ {
-		time.Sleep(TIMEOUT_SUBSCRIPTION)
-		resultRecordsList, response, err := wosClient.RecordsList(&watsonopenscalev2.RecordsListOptions{
-			DataSetID:         dataSetID,
-			IncludeTotalCount: core.BoolPtr(true),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error Listing Payload Records", "Could not list payload records, unexpected error: "+err.Error())
-			return
-		}
-		if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-			resp.Diagnostics.AddError("Unable to List Payload Records", err.Error())
-			return
-		}
-		records := resultRecordsList.(*watsonopenscalev2.RecordsListResponse)
-		if int(*records.TotalCount) >= numPayloadRecords {
-			tflog.Info(ctx, "Stored Payload Dataset", map[string]interface{}{"payload_records": *records.TotalCount})
-			break
-		}
+	resp.Diagnostics.Append(r.storePayload(ctx, &plan, dataSetID, createTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	plan.ID = types.StringValue(*result.Metadata.ID)
 
-	if _, err := os.Stat(plan.PayloadFile.ValueString()); err == nil || os.IsExist(err) {
-		os.Remove(plan.PayloadFile.ValueString())
-	}
+	removeLocalFile(plan.CleanupLocalFile, plan.PayloadFile.ValueString())
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -653,24 +532,21 @@ func (r *subscriptionResource) Read(ctx context.Context, req resource.ReadReques
 
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
 	subscription, response, err := wosClient.SubscriptionsGet(&watsonopenscalev2.SubscriptionsGetOptions{
 		SubscriptionID: core.StringPtr(state.ID.ValueString()),
 	})
-
-	if utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		state.ID = types.StringValue(*subscription.Metadata.ID)
-		state.Name = types.StringValue(*subscription.Entity.Deployment.Name)
-		diags = resp.State.Set(ctx, &state)
-	} else if response.StatusCode == 404 {
-		diags = resp.State.Set(ctx, &subscriptionResourceModel{})
-	} else {
-		resp.Diagnostics.AddError("Error Getting Subscription", "Could not read Subscription ID "+state.ID.ValueString()+": "+err.Error())
+	if !utils.CheckReadResponse(ctx, resp, "Error Getting Subscription", "Could not read Subscription ID "+state.ID.ValueString(), response, err) {
 		return
 	}
 
+	state.ID = types.StringValue(*subscription.Metadata.ID)
+	setSubscriptionState(&state, subscription.Entity)
+
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -695,6 +571,12 @@ func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, DEFAULT_TIMEOUT_SUBSCRIPTION)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var jsonPatches []watsonopenscalev2.PatchDocument
 
 	// for _, field := range updateableFields {
@@ -712,28 +594,63 @@ func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRe
 			Path:  core.StringPtr("/deployment/name"),
 			Value: plan.Name.ValueString(),
 		})
+	} else if plan.Deployment != nil && state.Deployment != nil && !plan.Deployment.Name.IsNull() && plan.Deployment.Name.ValueString() != state.Deployment.Name.ValueString() {
+		jsonPatches = append(jsonPatches, watsonopenscalev2.PatchDocument{
+			Op:    core.StringPtr("replace"),
+			Path:  core.StringPtr("/deployment/name"),
+			Value: plan.Deployment.Name.ValueString(),
+		})
 	}
 
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
-	result, response, err := wosClient.SubscriptionsUpdate(&watsonopenscalev2.SubscriptionsUpdateOptions{
-		SubscriptionID: core.StringPtr(state.ID.ValueString()),
-		PatchDocument:  jsonPatches,
-	})
+	plan.ID = state.ID
+	if len(jsonPatches) > 0 {
+		result, response, err := wosClient.SubscriptionsUpdate(&watsonopenscalev2.SubscriptionsUpdateOptions{
+			SubscriptionID: core.StringPtr(state.ID.ValueString()),
+			PatchDocument:  jsonPatches,
+		})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Error Updating Subscription", "Could not update subscription ID "+plan.ID.ValueString()+": "+err.Error())
-		return
-	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Update Subscription", err.Error())
-		return
+		if !utils.CheckResponse(&resp.Diagnostics, "Error Updating Subscription", "Could not update subscription ID "+plan.ID.ValueString(), response, err) {
+			return
+		}
+
+		plan.ID = types.StringValue(*result.Metadata.ID)
 	}
 
-	plan.ID = types.StringValue(*result.Metadata.ID)
+	if plan.PayloadFile.ValueString() == "" {
+		plan.PayloadChecksum = types.StringNull()
+	} else {
+		checksum, payloadChanged, err := plannedFileChecksum(plan.PayloadFile.ValueString(), plan.PayloadChecksum, state.PayloadChecksum)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read payload file", err.Error())
+			return
+		}
+		if payloadChanged {
+			resultDataSets, response, err := wosClient.DataSetsList(&watsonopenscalev2.DataSetsListOptions{
+				TargetTargetID:   core.StringPtr(state.ID.ValueString()),
+				Type:             core.StringPtr("payload_logging"),
+				TargetTargetType: core.StringPtr("subscription"),
+			})
+			if !utils.CheckResponse(&resp.Diagnostics, "Error Listing Datasets", "Could not list datasets", response, err) {
+				return
+			}
+			if len(resultDataSets.DataSets) == 0 {
+				resp.Diagnostics.AddError("Unable to List Datasets", "Could not find payload dataset for Subscription ID "+state.ID.ValueString()+".")
+				return
+			}
+			resp.Diagnostics.Append(r.storePayload(ctx, &plan, resultDataSets.DataSets[0].Metadata.ID, updateTimeout)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			removeLocalFile(plan.CleanupLocalFile, plan.PayloadFile.ValueString())
+		}
+		plan.PayloadChecksum = types.StringValue(checksum)
+	}
 	// plan.Name = types.StringValue(*result.Entity.Deployment.Name)
 
 	diags = resp.State.Set(ctx, plan)
@@ -751,24 +668,318 @@ func (r *subscriptionResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, DEFAULT_TIMEOUT_SUBSCRIPTION)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wosClient, err := r.client.WOSClient(ctx)
 	if err != nil {
-		panic(err)
+		resp.Diagnostics.AddError("Unable to get WOS Client", err.Error())
+		return
 	}
 
 	response, err := wosClient.SubscriptionsDelete(&watsonopenscalev2.SubscriptionsDeleteOptions{
 		SubscriptionID: core.StringPtr(state.ID.ValueString()),
 	})
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting Subscription", "Could not delete subscription ID "+state.ID.ValueString()+": "+err.Error())
+	if !utils.CheckDeleteResponse(&resp.Diagnostics, "Error Deleting Subscription", "Could not delete subscription ID "+state.ID.ValueString(), response, err) {
 		return
 	}
-	if !utils.Contains(utils.HTTP_OK, response.StatusCode) {
-		resp.Diagnostics.AddError("Unable to Delete Subscription", err.Error())
-		return
+
+	err = utils.WaitForDeletion(ctx, deleteTimeout, func(ctx context.Context) (*core.DetailedResponse, error) {
+		_, response, err := wosClient.SubscriptionsGetWithContext(ctx, &watsonopenscalev2.SubscriptionsGetOptions{
+			SubscriptionID: core.StringPtr(state.ID.ValueString()),
+		})
+		return response, err
+	})
+	utils.CheckWait(&resp.Diagnostics, "Error Deleting Subscription", "Subscription ID "+state.ID.ValueString()+" was not deleted", err)
+}
+
+// storePayload scores the records of payload_file against the deployment and
+// stores the scoring payload in the payload logging data set. It waits up to
+// timeout for the records to be stored.
+func (r *subscriptionResource) storePayload(ctx context.Context, plan *subscriptionResourceModel, dataSetID *string, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var numPayloadRecords int
+
+	wosClient, err := r.client.WOSClient(ctx)
+	if err != nil {
+		diags.AddError("Unable to get WOS Client", err.Error())
+		return diags
+	}
+
+	serviceProvider, response, err := wosClient.ServiceProvidersGet(&watsonopenscalev2.ServiceProvidersGetOptions{
+		ServiceProviderID: core.StringPtr(plan.ServiceProviderID.ValueString()),
+	})
+	if !utils.CheckResponse(&diags, "Error Getting Service Provider", "Could not get service provider", response, err) {
+		return diags
+	}
+
+	var scoringFields []string
+	var scoringValues [][]interface{}
+	var payloadContent []byte
+
+	if plan.PayloadFile.ValueString() != "" {
+		file, err := os.Open(plan.PayloadFile.ValueString())
+		if err != nil {
+			diags.AddError("Unable to open payload file", err.Error())
+			return diags
+		}
+		defer file.Close()
+		payloadContent, err = io.ReadAll(file)
+		if err != nil {
+			diags.AddError("Unable to read payload file", err.Error())
+			return diags
+		}
+		scoringFields, scoringValues, err = decodeFieldsValues(payloadContent)
+		if err != nil {
+			diags.AddError("Unable to parse payload file", err.Error())
+			return diags
+		}
+		numPayloadRecords = len(scoringValues)
+	}
+
+	// The data set already holds the records of earlier payloads on update.
+	recordsBefore, err := countRecords(ctx, wosClient, dataSetID)
+	if err != nil {
+		diags.AddError("Error Listing Payload Records", "Could not count records of dataset ID "+*dataSetID+": "+err.Error())
+		return diags
+	}
+	recordsExpected := recordsBefore + int64(numPayloadRecords)
+
+	var serviceType string
+	if serviceProvider.Entity != nil && serviceProvider.Entity.ServiceType != nil {
+		serviceType = *serviceProvider.Entity.ServiceType
+	}
+
+	if serviceType == "amazon_sagemaker" {
+		sess, err := session.NewSession(&aws.Config{
+			Credentials: credentials.NewStaticCredentials(plan.AWSAccessKeyID.ValueString(), plan.AWSSecretAccessKey.ValueString(), ""),
+			Region:      aws.String(plan.AWSRegion.ValueString())},
+		)
+		if err != nil {
+			diags.AddError("Unable to create AWS session", err.Error())
+			return diags
+		}
+
+		scoringPayload := fmt.Sprintf(`{"input_data": [%s]}`, string(payloadContent))
+		svc := sagemakerruntime.New(sess)
+		response, err := svc.InvokeEndpoint(&sagemakerruntime.InvokeEndpointInput{
+			EndpointName: aws.String("pipeline-endpoint"),
+			ContentType:  aws.String("application/json"),
+			Body:         []byte(scoringPayload),
+		})
+		if err != nil {
+			diags.AddError("Unable to score AWS endpoint", err.Error())
+			return diags
+		}
+
+		var jsonResponse map[string][]interface{}
+		err = json.Unmarshal(response.Body, &jsonResponse)
+		if err != nil {
+			diags.AddError("Unable to parse AWS endpoint response", err.Error())
+			return diags
+		}
+
+		values := []string{}
+		for _, v := range jsonResponse["predictions"] {
+			val, ok := v.(map[string]interface{})
+			if !ok {
+				diags.AddError("Unable to parse AWS endpoint response", fmt.Sprintf("Unexpected prediction %v.", v))
+				return diags
+			}
+			values = append(values, fmt.Sprintf(`[%f, "%s"]`, val["score"], val["predicted_label"]))
+		}
+
+		var jsonValues []interface{}
+		err = json.Unmarshal([]byte(fmt.Sprintf("[%s]", strings.Join(values, ","))), &jsonValues)
+		if err != nil {
+			diags.AddError("Unable to parse AWS endpoint response", err.Error())
+			return diags
+		}
+
+		_, resultResponse, err := wosClient.RecordsAdd(&watsonopenscalev2.RecordsAddOptions{
+			DataSetID: dataSetID,
+			DatasetRecordsPayloadItem: []watsonopenscalev2.DatasetRecordsPayloadItemIntf{
+				&watsonopenscalev2.DatasetRecordsPayloadItem{
+					Request: &watsonopenscalev2.ScoringPayloadRequestRequest{
+						Fields: scoringFields,
+						Values: scoringValues,
+					},
+					Response: &watsonopenscalev2.ScoringPayloadRequestResponse{
+						Fields: []string{"score", "predicted_label"},
+						Values: jsonValues,
+					},
+				},
+			},
+		})
+		if !utils.CheckResponse(&diags, "Error Storing Payload", "Could not store payload", resultResponse, err) {
+			return diags
+		}
+	}
+
+	if serviceType == "watson_machine_learning" && plan.PayloadFile.ValueString() != "" {
+		wmlClient, err := r.client.WMLClient(ctx)
+		if err != nil {
+			diags.AddError("Unable to get WML Client", err.Error())
+			return diags
+		}
+
+		_, response, err = wmlClient.DeploymentsComputePredictions(&watsonmachinelearningv4.DeploymentsComputePredictionsOptions{
+			DeploymentID: core.StringPtr(plan.Deployment.DeploymentID.ValueString()),
+			InputData: []watsonmachinelearningv4.InputDataArray{
+				{Fields: scoringFields, Values: scoringValues},
+			},
+		})
+		if !utils.CheckResponse(&diags, "Error Scoring Payload", "Could not score payload", response, err) {
+			return diags
+		}
+	}
+
+	// The records are stored asynchronously, the waiter reports "storing"
+	// until the data set contains all of them.
+	recordsWaiter := utils.StateWaiter[int64]{
+		Pending: []string{"storing"},
+		Target:  []string{"stored"},
+		Refresh: func(ctx context.Context) (int64, string, error) {
+			totalCount, err := countRecords(ctx, wosClient, dataSetID)
+			if err != nil {
+				return 0, "", err
+			}
+			return totalCount, utils.If(totalCount >= recordsExpected, "stored", "storing"), nil
+		},
+		Timeout: timeout,
+	}
+	totalCount, err := recordsWaiter.Wait(ctx)
+	if utils.CheckWait(&diags, "Error Storing Payload Records", fmt.Sprintf("%d of %d records are stored in payload dataset ID %s", totalCount, recordsExpected, *dataSetID), err) {
+		tflog.Info(ctx, "Stored Payload Dataset", map[string]interface{}{"payload_records": totalCount})
 	}
+
+	return diags
 }
 
 func (r *subscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// countRecords returns the total number of records in a data set.
+func countRecords(ctx context.Context, wosClient *watsonopenscalev2.WatsonOpenScaleV2, dataSetID *string) (int64, error) {
+	resultRecordsList, response, err := wosClient.RecordsListWithContext(ctx, &watsonopenscalev2.RecordsListOptions{
+		DataSetID:         dataSetID,
+		IncludeTotalCount: core.BoolPtr(true),
+	})
+	if err := utils.ResponseError(response, err); err != nil {
+		return 0, err
+	}
+	records, ok := resultRecordsList.(*watsonopenscalev2.RecordsListResponse)
+	if !ok || records.TotalCount == nil {
+		return 0, fmt.Errorf("total count of records missing in response")
+	}
+	return *records.TotalCount, nil
+}
+
+// waitForDataSet waits until the data set of the given type of a subscription
+// exists and is active.
+func waitForDataSet(ctx context.Context, wosClient *watsonopenscalev2.WatsonOpenScaleV2, subscriptionID string, dataSetType string, timeout time.Duration) (*watsonopenscalev2.DataSetResponse, error) {
+	waiter := utils.StateWaiter[*watsonopenscalev2.DataSetResponse]{
+		Pending: []string{watsonopenscalev2.Status_State_Preparing},
+		Target:  []string{watsonopenscalev2.Status_State_Active},
+		Failed:  []string{watsonopenscalev2.Status_State_Error},
+		Refresh: func(ctx context.Context) (*watsonopenscalev2.DataSetResponse, string, error) {
+			dataSets, response, err := wosClient.DataSetsListWithContext(ctx, &watsonopenscalev2.DataSetsListOptions{
+				TargetTargetID:   core.StringPtr(subscriptionID),
+				Type:             core.StringPtr(dataSetType),
+				TargetTargetType: core.StringPtr("subscription"),
+			})
+			if err := utils.ResponseError(response, err); err != nil {
+				return nil, "", err
+			}
+			if len(dataSets.DataSets) == 0 {
+				// The data set is created asynchronously.
+				return nil, "", nil
+			}
+			dataSet := &dataSets.DataSets[0]
+			if dataSet.Entity == nil || dataSet.Entity.Status == nil || dataSet.Entity.Status.State == nil {
+				return nil, "", fmt.Errorf("data set status missing in response")
+			}
+			return dataSet, *dataSet.Entity.Status.State, nil
+		},
+		FailureMessage: func(dataSet *watsonopenscalev2.DataSetResponse) string {
+			return openScaleFailureMessage(dataSet.Entity.Status.Failure)
+		},
+		Timeout: timeout,
+	}
+	return waiter.Wait(ctx)
+}
+
+// openScaleFailureMessage joins the messages of an OpenScale failure.
+func openScaleFailureMessage(failure *watsonopenscalev2.GenericErrorResponse) string {
+	if failure == nil {
+		return ""
+	}
+	var messages []string
+	for _, v := range failure.Errors {
+		if v.Message != nil {
+			messages = append(messages, *v.Message)
+		}
+	}
+	return strings.Join(messages, " ")
+}
+
+// setSubscriptionState refreshes the configurable attributes of state from
+// entity.
+func setSubscriptionState(state *subscriptionResourceModel, entity *watsonopenscalev2.SubscriptionResponseEntity) {
+	if entity == nil {
+		return
+	}
+	if entity.DataMartID != nil {
+		state.DataMartID = types.StringValue(*entity.DataMartID)
+	}
+	if entity.ServiceProviderID != nil {
+		state.ServiceProviderID = types.StringValue(*entity.ServiceProviderID)
+	}
+
+	if asset := entity.Asset; asset != nil {
+		if state.Asset == nil {
+			state.Asset = &subscriptionAssetModel{}
+		}
+		state.Asset.AssetID = utils.StringValueOrNull(asset.AssetID)
+		state.Asset.AssetType = utils.StringValueOrNull(asset.AssetType)
+		state.Asset.InputDataType = utils.StringValueOrNull(asset.InputDataType)
+		state.Asset.ProblemType = utils.StringValueOrNull(asset.ProblemType)
+		state.Asset.URL = utils.StringValueOrNull(asset.URL)
+	}
+
+	if deployment := entity.Deployment; deployment != nil {
+		if deployment.Name != nil {
+			state.Name = types.StringValue(*deployment.Name)
+		}
+		if state.Deployment == nil {
+			state.Deployment = &subscriptionDeploymentModel{
+				URL:        types.StringNull(),
+				ScoringURL: types.StringNull(),
+				Name:       types.StringNull(),
+			}
+		}
+		state.Deployment.DeploymentID = utils.StringValueOrNull(deployment.DeploymentID)
+		state.Deployment.DeploymentType = utils.StringValueOrNull(deployment.DeploymentType)
+		state.Deployment.URL = utils.RefreshOptionalString(state.Deployment.URL, deployment.URL)
+		state.Deployment.Name = utils.RefreshOptionalString(state.Deployment.Name, deployment.Name)
+		var scoringURL *string
+		if deployment.ScoringEndpoint != nil {
+			scoringURL = deployment.ScoringEndpoint.URL
+		}
+		state.Deployment.ScoringURL = utils.RefreshOptionalString(state.Deployment.ScoringURL, scoringURL)
+	}
+
+	if properties := entity.AssetProperties; properties != nil {
+		state.AssetProperties = &subscriptionAssetPropertiesModel{
+			CategoricalFields: utils.StringList(properties.CategoricalFields),
+			FeatureFields:     utils.StringList(properties.FeatureFields),
+			LabelColumn:       utils.StringValueOrNull(properties.LabelColumn),
+			PredictionField:   utils.StringValueOrNull(properties.PredictionField),
+			ProbabilityFields: utils.StringList(properties.ProbabilityFields),
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const DEFAULT_PROFILE = "default"
+
+// DefaultCredentialsFile returns the path of the credentials file, which is
+// IBMCPD_CREDENTIALS_FILE if set and ~/.ibmcpd/credentials otherwise.
+func DefaultCredentialsFile() string {
+	if file := os.Getenv("IBMCPD_CREDENTIALS_FILE"); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ibmcpd", "credentials")
+}
+
+// LoadProfile reads the named profile from an INI style credentials file:
+//
+//	[default]
+//	url      = https://cpd.example.com
+//	username = admin
+//	api_key  = ...
+//
+// Lines starting with # or ; are comments, keys before the first section are
+// ignored. The keys of the profile are returned in lower case. A missing file or profile is reported as an error
+// that satisfies errors.Is(err, os.ErrNotExist).
+func LoadProfile(path string, name string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var profile map[string]string
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == name && profile == nil {
+				profile = map[string]string{}
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNumber)
+		}
+		if profile != nil && section == name {
+			profile[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("profile %q not found in %s: %w", name, path, os.ErrNotExist)
+	}
+	return profile, nil
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "default",
+			content: `# IBM Cloud Pak for Data
+[default]
+url      = https://cpd.example.com
+Username = admin
+api_key  = "secret"
+`,
+			profile: DEFAULT_PROFILE,
+			want:    map[string]string{"url": "https://cpd.example.com", "username": "admin", "api_key": "secret"},
+		},
+		{
+			name: "named",
+			content: `[default]
+url = https://cpd.example.com
+
+; staging cluster
+[ staging ]
+url = https://staging.example.com
+password = a=b
+`,
+			profile: "staging",
+			want:    map[string]string{"url": "https://staging.example.com", "password": "a=b"},
+		},
+		{
+			name: "key before section",
+			content: `url = https://ignored.example.com
+[default]
+username = admin
+`,
+			profile: DEFAULT_PROFILE,
+			want:    map[string]string{"username": "admin"},
+		},
+		{
+			name: "key before section without profile name",
+			content: `url = https://ignored.example.com
+[default]
+username = admin
+`,
+			profile: "",
+			wantErr: `profile "" not found`,
+		},
+		{
+			name:    "empty profile",
+			content: "[default]\n",
+			profile: DEFAULT_PROFILE,
+			want:    map[string]string{},
+		},
+		{
+			name:    "missing profile",
+			content: "[default]\nurl = https://cpd.example.com\n",
+			profile: "staging",
+			wantErr: `profile "staging" not found`,
+		},
+		{
+			name:    "invalid line",
+			content: "[default]\nurl\n",
+			profile: DEFAULT_PROFILE,
+			wantErr: ":2: expected key = value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadProfile(path, tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadProfile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadProfile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadProfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadProfileNotExist(t *testing.T) {
+	_, err := LoadProfile(filepath.Join(t.TempDir(), "credentials"), DEFAULT_PROFILE)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("LoadProfile() error = %v, want %v", err, os.ErrNotExist)
+	}
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte("[default]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadProfile(path, "staging")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("LoadProfile() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
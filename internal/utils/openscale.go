@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+const (
+	// RESOURCE_CONTROLLER_URL is the public endpoint of the IBM Cloud resource
+	// controller. The private endpoint is
+	// https://private.resource-controller.cloud.ibm.com.
+	RESOURCE_CONTROLLER_URL = "https://resource-controller.cloud.ibm.com"
+
+	// OPENSCALE_RESOURCE_ID is the catalog ID of the Watson OpenScale service.
+	OPENSCALE_RESOURCE_ID = "2ad019f3-0fd6-4c25-966d-f3952481a870"
+)
+
+// OpenScaleInstance is a Watson OpenScale service instance of the account.
+type OpenScaleInstance struct {
+	GUID            string `json:"guid"`
+	CRN             string `json:"crn"`
+	Name            string `json:"name"`
+	RegionID        string `json:"region_id"`
+	ResourceGroupID string `json:"resource_group_id"`
+	ResourceID      string `json:"resource_id"`
+	State           string `json:"state"`
+}
+
+// OpenScaleInstanceFilter restricts the instances returned by
+// ListOpenScaleInstances. Empty fields do not filter.
+type OpenScaleInstanceFilter struct {
+	Name            string
+	Region          string
+	ResourceGroupID string
+}
+
+// ListOpenScaleInstances returns the Watson OpenScale instances of the account
+// from the resource controller at resourceControllerURL, following all pages.
+// Name and resource group are filtered server side, region is filtered on the
+// returned instances.
+func ListOpenScaleInstances(ctx context.Context, auth core.Authenticator, httpClient *http.Client, resourceControllerURL string, filter OpenScaleInstanceFilter) ([]OpenScaleInstance, error) {
+	query := url.Values{}
+	query.Set("resource_id", OPENSCALE_RESOURCE_ID)
+	query.Set("limit", "100")
+	if filter.Name != "" {
+		query.Set("name", filter.Name)
+	}
+	if filter.ResourceGroupID != "" {
+		query.Set("resource_group_id", filter.ResourceGroupID)
+	}
+	next := "/v2/resource_instances?" + query.Encode()
+
+	var instances []OpenScaleInstance
+	for next != "" {
+		var page struct {
+			NextURL   string              `json:"next_url"`
+			Resources []OpenScaleInstance `json:"resources"`
+		}
+		if err := getResourceControllerPage(ctx, auth, httpClient, resourceControllerURL, next, &page); err != nil {
+			return nil, err
+		}
+		for _, instance := range page.Resources {
+			if instance.ResourceID != OPENSCALE_RESOURCE_ID {
+				continue
+			}
+			if filter.Region != "" && instance.RegionID != filter.Region {
+				continue
+			}
+			instances = append(instances, instance)
+		}
+		next = page.NextURL
+	}
+	return instances, nil
+}
+
+// GetOpenScaleGuid returns the GUID of the only active Watson OpenScale
+// instance of the account. It fails if there is none or more than one, in
+// which case the instance has to be configured explicitly.
+func GetOpenScaleGuid(ctx context.Context, auth core.Authenticator, httpClient *http.Client, resourceControllerURL string, region string) (string, error) {
+	instances, err := ListOpenScaleInstances(ctx, auth, httpClient, resourceControllerURL, OpenScaleInstanceFilter{Region: region})
+	if err != nil {
+		return "", fmt.Errorf("unable to list Watson OpenScale instances: %w", err)
+	}
+
+	var active []OpenScaleInstance
+	for _, instance := range instances {
+		if instance.State == "active" {
+			active = append(active, instance)
+		}
+	}
+	switch len(active) {
+	case 0:
+		return "", fmt.Errorf("no active Watson OpenScale instance found in region %s", region)
+	case 1:
+		return active[0].GUID, nil
+	}
+
+	names := make([]string, len(active))
+	for i, instance := range active {
+		names[i] = instance.Name + " (" + instance.GUID + ")"
+	}
+	return "", fmt.Errorf("found %d Watson OpenScale instances in region %s, set openscale_instance_id to one of %s", len(active), region, strings.Join(names, ", "))
+}
+
+func getResourceControllerPage(ctx context.Context, auth core.Authenticator, httpClient *http.Client, resourceControllerURL string, path string, result interface{}) error {
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	URL, err := url.Parse(strings.TrimSuffix(resourceControllerURL, "/") + path)
+	if err != nil {
+		return err
+	}
+	builder.URL = URL
+	builder.AddHeader("Accept", "application/json")
+	request, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	err = auth.Authenticate(request)
+	if err != nil {
+		return err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if err := BodyError(response.StatusCode, content); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, result); err != nil {
+		return fmt.Errorf("unable to decode resource controller response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// IsNotFound reports whether response has status code 404.
+func IsNotFound(response *core.DetailedResponse) bool {
+	return response != nil && response.StatusCode == http.StatusNotFound
+}
+
+// ResponseError returns an error describing a failed API call, or nil when
+// the call succeeded. Transport errors, non-2xx responses and responses that
+// could not be decoded are all reported.
+func ResponseError(response *core.DetailedResponse, err error) error {
+	if response == nil {
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		return errors.New("request failed: no response received")
+	}
+	if Contains(HTTP_OK, response.StatusCode) {
+		return err
+	}
+
+	message := responseErrorMessage(response)
+	if message == "" && err != nil {
+		message = err.Error()
+	}
+	if message == "" {
+		message = http.StatusText(response.StatusCode)
+	}
+	return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, message)
+}
+
+// CheckResponse adds an error to diags and returns false when an API call
+// failed. detail describes the call, e.g. "Could not create space".
+func CheckResponse(diags *diag.Diagnostics, summary string, detail string, response *core.DetailedResponse, err error) bool {
+	if err := ResponseError(response, err); err != nil {
+		diags.AddError(summary, detail+", "+err.Error())
+		return false
+	}
+	return true
+}
+
+// CheckReadResponse is CheckResponse for the request that reads a resource
+// in Read. On 404 the resource is removed from state so that it is planned
+// for creation again.
+func CheckReadResponse(ctx context.Context, resp *resource.ReadResponse, summary string, detail string, response *core.DetailedResponse, err error) bool {
+	if IsNotFound(response) {
+		resp.State.RemoveResource(ctx)
+		return false
+	}
+	return CheckResponse(&resp.Diagnostics, summary, detail, response, err)
+}
+
+// CheckDeleteResponse is CheckResponse for the request that deletes a
+// resource in Delete. A resource that no longer exists is not an error.
+func CheckDeleteResponse(diags *diag.Diagnostics, summary string, detail string, response *core.DetailedResponse, err error) bool {
+	if IsNotFound(response) {
+		return true
+	}
+	return CheckResponse(diags, summary, detail, response, err)
+}
+
+// BodyError returns an error describing a failed hand-built request, or nil
+// when statusCode is 2xx. content is the response body.
+func BodyError(statusCode int, content []byte) error {
+	if Contains(HTTP_OK, statusCode) {
+		return nil
+	}
+	message := responseErrorMessage(&core.DetailedResponse{StatusCode: statusCode, RawResult: content})
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	return fmt.Errorf("unexpected status code %d: %s", statusCode, message)
+}
+
+// responseErrorMessage extracts the error message from the body of a failed
+// response. WML, OpenScale and the platform APIs return either an "errors"
+// list with code and message or a single "error" or "message" field.
+func responseErrorMessage(response *core.DetailedResponse) string {
+	body, ok := response.Result.(map[string]interface{})
+	if !ok {
+		if len(response.RawResult) == 0 || json.Unmarshal(response.RawResult, &body) != nil {
+			return strings.TrimSpace(string(response.RawResult))
+		}
+	}
+
+	var message string
+	if list, ok := body["errors"].([]interface{}); ok && len(list) > 0 {
+		if first, ok := list[0].(map[string]interface{}); ok {
+			code, _ := first["code"].(string)
+			message, _ = first["message"].(string)
+			if code != "" && message != "" {
+				message = code + ": " + message
+			}
+		}
+	}
+	for _, key := range []string{"error", "message", "errorMessage"} {
+		if message != "" {
+			break
+		}
+		switch value := body[key].(type) {
+		case string:
+			message = value
+		case map[string]interface{}:
+			message, _ = value["message"].(string)
+		}
+	}
+	if message == "" {
+		return ""
+	}
+	if trace, ok := body["trace"].(string); ok && trace != "" {
+		message += " (trace: " + trace + ")"
+	}
+	return message
+}
@@ -1,14 +1,16 @@
 package utils
 
 import (
-	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
-	"net/url"
+	"math/big"
+	"net/http"
+	"os"
 	"reflect"
-	"strings"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -53,87 +55,188 @@ func ConvertString(arr []types.String) []string {
 	return arrString
 }
 
-func GetAuthenticator(url string, username string, password string, apiKey string) (core.Authenticator, error) {
-	if strings.Contains(url, "cloud.ibm.com") {
-		auth := core.NewIamAuthenticatorBuilder()
-		auth.ApiKey = apiKey
-		err := auth.Validate()
-		return auth, err
-	} else {
-		if username != "" && apiKey != "" {
-			auth, err := core.NewCloudPakForDataAuthenticatorUsingAPIKey(fmt.Sprintf("%s/icp4d-api", url), username, apiKey, true, map[string]string{})
-			if err != nil {
-				return nil, err
-			}
-			err = auth.Validate()
-			return auth, err
-		}
-
-		if username != "" && password != "" {
-			auth, err := core.NewCloudPakForDataAuthenticatorUsingPassword(fmt.Sprintf("%s/icp4d-api", url), username, password, true, map[string]string{})
-			if err != nil {
-				return nil, err
-			}
-			err = auth.Validate()
-			return auth, err
-		}
+func StringValueOrNull(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
 	}
-	return nil, errors.New("unable to parse credentials")
+	return types.StringValue(*value)
 }
 
-func GetOpenScaleGuid(auth core.Authenticator) (string, error) {
-	builder := core.NewRequestBuilder(core.GET)
-	builder = builder.WithContext(context.Background())
-	URL, err := url.Parse("https://resource-controller.cloud.ibm.com/v2/resource_instances")
-	if err != nil {
-		return "", err
+// RefreshOptionalString returns the value read from the API for an optional
+// attribute, but keeps it null when it was not set in the prior state.
+func RefreshOptionalString(prior types.String, value *string) types.String {
+	if prior.IsNull() {
+		return prior
 	}
-	builder.URL = URL
-	builder.AddHeader("Content-Type", "application/json")
-	request, err := builder.Build()
-	if err != nil {
-		return "", err
+	return StringValueOrNull(value)
+}
+
+// StringList converts API values to a list attribute, empty rather than null.
+func StringList(values []string) []types.String {
+	list := make([]types.String, len(values))
+	for i, v := range values {
+		list[i] = types.StringValue(v)
 	}
-	err = auth.Authenticate(request)
-	if err != nil {
-		return "", err
+	return list
+}
+
+// RefreshNumber returns the value read from the API as a number attribute,
+// keeping the prior value when it is equal as float64 so that numbers set in
+// the configuration with a higher precision do not show up as a change.
+func RefreshNumber(prior types.Number, value float64) types.Number {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if priorValue, _ := prior.ValueBigFloat().Float64(); priorValue == value {
+			return prior
+		}
 	}
+	return types.NumberValue(big.NewFloat(value))
+}
 
-	serviceOptions := &core.ServiceOptions{
-		Authenticator: auth,
+// AddHeaders adds headers, e.g. the default headers of a service, to a
+// request built by hand.
+func AddHeaders(builder *core.RequestBuilder, headers http.Header) {
+	for name := range headers {
+		builder.AddHeader(name, headers.Get(name))
 	}
+}
 
-	service, err := core.NewBaseService(serviceOptions)
+func FileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return "", err
 	}
-
-	response, err := service.Client.Do(request)
-	if err != nil {
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
 		return "", err
 	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
 
-	defer response.Body.Close()
-	content, err := io.ReadAll(response.Body)
-	if err != nil {
-		panic(err)
-	}
+const (
+	AUTH_TYPE_CP4D_PASSWORD = "cp4d_password"
+	AUTH_TYPE_CP4D_APIKEY   = "cp4d_apikey"
+	AUTH_TYPE_IAM           = "iam"
+	AUTH_TYPE_BEARER_TOKEN  = "bearer_token"
+	AUTH_TYPE_CONTAINER     = "container"
+	AUTH_TYPE_ZEN_APIKEY    = "zen_apikey"
+)
 
-	var jsonResponse map[string]interface{}
-	err = json.Unmarshal(content, &jsonResponse)
-	if err != nil {
-		panic(err)
+var AUTH_TYPES = []string{
+	AUTH_TYPE_CP4D_PASSWORD,
+	AUTH_TYPE_CP4D_APIKEY,
+	AUTH_TYPE_IAM,
+	AUTH_TYPE_BEARER_TOKEN,
+	AUTH_TYPE_CONTAINER,
+	AUTH_TYPE_ZEN_APIKEY,
+}
+
+// AuthConfig holds the credentials of all authentication types. Only the
+// fields of the selected type are used.
+type AuthConfig struct {
+	Type        string
+	URL         string
+	AuthURL     string
+	PublicCloud bool
+
+	Username    string
+	Password    string
+	ApiKey      string
+	BearerToken string
+
+	CRTokenFilename string
+	IAMProfileName  string
+	IAMProfileID    string
+
+	HTTPClient *http.Client
+}
+
+// DefaultAuthType returns the authentication type used when auth_type is not
+// set, based on the credentials that are present.
+func (c *AuthConfig) DefaultAuthType() string {
+	switch {
+	case c.BearerToken != "":
+		return AUTH_TYPE_BEARER_TOKEN
+	case c.PublicCloud:
+		return AUTH_TYPE_IAM
+	case c.ApiKey != "":
+		return AUTH_TYPE_CP4D_APIKEY
+	default:
+		return AUTH_TYPE_CP4D_PASSWORD
 	}
+}
 
-	var openScaleGuid string
-	resources := jsonResponse["resources"].([]interface{})
-	for _, resource := range resources {
-		resourceId := resource.(map[string]interface{})["resource_id"]
-		if resourceId == "2ad019f3-0fd6-4c25-966d-f3952481a870" {
-			openScaleGuid = resource.(map[string]interface{})["guid"].(string)
-			break
+func GetAuthenticator(config AuthConfig) (core.Authenticator, error) {
+	authType := If(config.Type != "", config.Type, config.DefaultAuthType())
+	authURL := If(config.AuthURL != "", config.AuthURL, fmt.Sprintf("%s/icp4d-api", config.URL))
+
+	switch authType {
+	case AUTH_TYPE_BEARER_TOKEN:
+		return core.NewBearerTokenAuthenticator(config.BearerToken)
+	case AUTH_TYPE_IAM:
+		builder := core.NewIamAuthenticatorBuilder().
+			SetApiKey(config.ApiKey).
+			SetClient(config.HTTPClient)
+		if config.AuthURL != "" {
+			builder.SetURL(config.AuthURL)
+		}
+		return builder.Build()
+	case AUTH_TYPE_CONTAINER:
+		builder := core.NewContainerAuthenticatorBuilder().
+			SetIAMProfileName(config.IAMProfileName).
+			SetIAMProfileID(config.IAMProfileID).
+			SetClient(config.HTTPClient)
+		if config.CRTokenFilename != "" {
+			builder.SetCRTokenFilename(config.CRTokenFilename)
+		}
+		if config.AuthURL != "" {
+			builder.SetURL(config.AuthURL)
 		}
+		return builder.Build()
+	case AUTH_TYPE_CP4D_APIKEY:
+		auth, err := core.NewCloudPakForDataAuthenticatorUsingAPIKey(authURL, config.Username, config.ApiKey, false, map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		auth.Client = config.HTTPClient
+		return auth, nil
+	case AUTH_TYPE_CP4D_PASSWORD:
+		auth, err := core.NewCloudPakForDataAuthenticatorUsingPassword(authURL, config.Username, config.Password, false, map[string]string{})
+		if err != nil {
+			return nil, err
+		}
+		auth.Client = config.HTTPClient
+		return auth, nil
+	case AUTH_TYPE_ZEN_APIKEY:
+		auth := &ZenApiKeyAuthenticator{Username: config.Username, ApiKey: config.ApiKey}
+		return auth, auth.Validate()
 	}
+	return nil, fmt.Errorf("unsupported authentication type %q", authType)
+}
 
-	return openScaleGuid, nil
+// ZenApiKeyAuthenticator authenticates with a Cloud Pak for Data platform API
+// key, which is sent as is and not exchanged for a token.
+type ZenApiKeyAuthenticator struct {
+	Username string
+	ApiKey   string
+}
+
+func (a *ZenApiKeyAuthenticator) AuthenticationType() string {
+	return "zenapikey"
+}
+
+func (a *ZenApiKeyAuthenticator) Authenticate(request *http.Request) error {
+	token := base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.ApiKey))
+	request.Header.Set("Authorization", "ZenApiKey "+token)
+	return nil
+}
+
+func (a *ZenApiKeyAuthenticator) Validate() error {
+	if a.Username == "" {
+		return errors.New("the Username property is required")
+	}
+	if a.ApiKey == "" {
+		return errors.New("the ApiKey property is required")
+	}
+	return nil
 }
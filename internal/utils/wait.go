@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	WAIT_MIN_INTERVAL = 2 * time.Second
+	WAIT_MAX_INTERVAL = 30 * time.Second
+)
+
+// StateRefreshFunc reads a resource and returns it together with its state,
+// e.g. "initializing". An empty state means the state is not known yet.
+type StateRefreshFunc[T any] func(ctx context.Context) (result T, state string, err error)
+
+// StateWaiter waits for a resource to reach one of the Target states.
+type StateWaiter[T any] struct {
+	// Pending are the states of a resource that is still in progress. Other
+	// states than Pending, Target and Failed are reported as unexpected.
+	Pending []string
+	Target  []string
+	Failed  []string
+
+	Refresh StateRefreshFunc[T]
+
+	// FailureMessage describes why a resource reached a Failed state.
+	// Optional.
+	FailureMessage func(result T) string
+
+	// Timeout limits the time to wait in addition to the deadline of the
+	// context. Zero means no additional limit.
+	Timeout time.Duration
+
+	// MinInterval is the initial wait between refreshes. It doubles after
+	// every refresh up to WAIT_MAX_INTERVAL. Defaults to WAIT_MIN_INTERVAL.
+	MinInterval time.Duration
+}
+
+// Wait calls Refresh until the resource reaches a Target state, reaches a
+// Failed or unexpected state, Refresh fails, or the context is done, e.g.
+// because the timeout expired or the user interrupted the apply. It returns
+// the last result read, also on error, so that the caller can save it.
+func (w *StateWaiter[T]) Wait(ctx context.Context) (T, error) {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+	interval := If(w.MinInterval > 0, w.MinInterval, WAIT_MIN_INTERVAL)
+
+	var last T
+	lastState := "unknown"
+	for {
+		result, state, err := w.Refresh(ctx)
+		if ctx.Err() != nil {
+			return last, w.contextError(ctx, lastState)
+		}
+		if err != nil {
+			return last, err
+		}
+		last = result
+		if state != "" {
+			lastState = state
+		}
+
+		switch {
+		case Contains(w.Target, state):
+			return last, nil
+		case Contains(w.Failed, state):
+			message := ""
+			if w.FailureMessage != nil {
+				message = w.FailureMessage(result)
+			}
+			if message == "" {
+				return last, fmt.Errorf("reached state %s", state)
+			}
+			return last, fmt.Errorf("reached state %s: %s", state, message)
+		case state != "" && !Contains(w.Pending, state):
+			return last, fmt.Errorf("unexpected state %s, expected one of %s", state, strings.Join(append(append([]string{}, w.Pending...), w.Target...), ", "))
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return last, w.contextError(ctx, lastState)
+		case <-timer.C:
+		}
+		interval = nextWaitInterval(interval)
+	}
+}
+
+// nextWaitInterval doubles interval up to WAIT_MAX_INTERVAL.
+func nextWaitInterval(interval time.Duration) time.Duration {
+	return If(interval*2 < WAIT_MAX_INTERVAL, interval*2, WAIT_MAX_INTERVAL)
+}
+
+func (w *StateWaiter[T]) contextError(ctx context.Context, lastState string) error {
+	target := strings.Join(w.Target, ", ")
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timeout while waiting for state %s, last state: %s", target, lastState)
+	}
+	return fmt.Errorf("stopped waiting for state %s, last state: %s: %w", target, lastState, ctx.Err())
+}
+
+// WaitForDeletion waits until get reports that a resource deleted
+// asynchronously is gone, i.e. returns 404.
+func WaitForDeletion(ctx context.Context, timeout time.Duration, get func(ctx context.Context) (*core.DetailedResponse, error)) error {
+	waiter := StateWaiter[struct{}]{
+		Pending: []string{"deleting"},
+		Target:  []string{"deleted"},
+		Refresh: func(ctx context.Context) (struct{}, string, error) {
+			response, err := get(ctx)
+			if IsNotFound(response) {
+				return struct{}{}, "deleted", nil
+			}
+			if err := ResponseError(response, err); err != nil {
+				return struct{}{}, "", err
+			}
+			return struct{}{}, "deleting", nil
+		},
+		Timeout: timeout,
+	}
+	_, err := waiter.Wait(ctx)
+	return err
+}
+
+// CheckWait adds an error to diags and returns false when waiting for a
+// state failed. detail describes the resource, e.g. "Deployment ID 1234 is
+// not ready".
+func CheckWait(diags *diag.Diagnostics, summary string, detail string, err error) bool {
+	if err != nil {
+		diags.AddError(summary, detail+", "+err.Error())
+		return false
+	}
+	return true
+}
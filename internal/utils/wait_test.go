@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextWaitInterval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		want     time.Duration
+	}{
+		{interval: time.Millisecond, want: 2 * time.Millisecond},
+		{interval: WAIT_MIN_INTERVAL, want: 2 * WAIT_MIN_INTERVAL},
+		{interval: 14 * time.Second, want: 28 * time.Second},
+		{interval: 20 * time.Second, want: WAIT_MAX_INTERVAL},
+		{interval: WAIT_MAX_INTERVAL, want: WAIT_MAX_INTERVAL},
+	}
+	for _, tt := range tests {
+		if got := nextWaitInterval(tt.interval); got != tt.want {
+			t.Errorf("nextWaitInterval(%s) = %s, want %s", tt.interval, got, tt.want)
+		}
+	}
+}
+
+func TestStateWaiterWait(t *testing.T) {
+	tests := []struct {
+		name           string
+		states         []string
+		refreshErr     error
+		failureMessage string
+		timeout        time.Duration
+		want           int
+		wantErr        string
+	}{
+		{
+			name:   "target",
+			states: []string{"pending", "pending", "ready"},
+			want:   3,
+		},
+		{
+			name:   "unknown state",
+			states: []string{"", "pending", "ready"},
+			want:   3,
+		},
+		{
+			name:    "failed",
+			states:  []string{"pending", "failed"},
+			want:    2,
+			wantErr: "reached state failed",
+		},
+		{
+			name:           "failed with message",
+			states:         []string{"failed"},
+			failureMessage: "out of memory",
+			want:           1,
+			wantErr:        "reached state failed: out of memory",
+		},
+		{
+			name:    "unexpected",
+			states:  []string{"pending", "deleted"},
+			want:    2,
+			wantErr: "unexpected state deleted, expected one of pending, ready",
+		},
+		{
+			name:       "refresh error",
+			states:     []string{"pending"},
+			refreshErr: errors.New("connection refused"),
+			want:       1,
+			wantErr:    "connection refused",
+		},
+		{
+			name:    "timeout",
+			timeout: 20 * time.Millisecond,
+			wantErr: "timeout while waiting for state ready, last state: pending",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			waiter := StateWaiter[int]{
+				Pending: []string{"pending"},
+				Target:  []string{"ready"},
+				Failed:  []string{"failed"},
+				Refresh: func(ctx context.Context) (int, string, error) {
+					if calls >= len(tt.states) {
+						if tt.refreshErr != nil {
+							return 0, "", tt.refreshErr
+						}
+						// Keep the resource pending until the timeout.
+						return calls, "pending", nil
+					}
+					calls++
+					return calls, tt.states[calls-1], nil
+				},
+				FailureMessage: func(result int) string {
+					return tt.failureMessage
+				},
+				Timeout:     tt.timeout,
+				MinInterval: time.Millisecond,
+			}
+			got, err := waiter.Wait(context.Background())
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Wait() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.timeout == 0 && got != tt.want {
+				t.Errorf("Wait() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateWaiterWaitCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	waiter := StateWaiter[int]{
+		Pending: []string{"pending"},
+		Target:  []string{"ready"},
+		Refresh: func(ctx context.Context) (int, string, error) {
+			cancel()
+			return 1, "pending", nil
+		},
+		MinInterval: time.Millisecond,
+	}
+	_, err := waiter.Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait() error = %v, want %v", err, context.Canceled)
+	}
+}